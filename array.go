@@ -1,21 +1,69 @@
 package jsonassert
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
 
 func (a *Asserter) checkArray(path string, act, exp []interface{}) {
 	a.tt.Helper()
-	if len(exp) > 0 && exp[0] == "<<UNORDERED>>" {
+	if rest := a.checkArrayConstraints(path, act, exp); len(rest) != len(exp) {
+		if len(rest) == 0 {
+			// Only the array as a whole was constrained, not its elements.
+			return
+		}
+		exp = rest
+	}
+	name, err := a.arrayDirective(exp)
+	if err != nil {
+		directive, _ := exp[0].(string)
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	switch name {
+	case "UNORDERED":
 		a.checkArrayUnordered(path, act, exp[1:])
-	} else {
+	case "CONTAINS":
+		a.checkArrayContains(path, act, exp[1:])
+	case "CONTAINS_ORDERED":
+		a.checkArrayContainsOrdered(path, act, exp[1:])
+	case "ONLY":
+		a.checkArrayOnly(path, act, exp[1:])
+	case "EACH":
+		a.checkArrayEach(path, act, exp)
+	default:
 		a.checkArrayOrdered(path, act, exp)
 	}
 }
 
-//nolint:gocognit,gocyclo,cyclop // function is actually still readable
+// arrayDirective returns the name of the directive given as the first element
+// of the expected array, if any. Only "<<EACH>>" takes an argument, and
+// "<<CONTAINS:s>>" is the string directive rather than an array directive.
+func (a *Asserter) arrayDirective(exp []interface{}) (string, error) {
+	if len(exp) == 0 {
+		return "", nil
+	}
+	s, ok := exp[0].(string)
+	if !ok {
+		return "", nil
+	}
+	name, arg, _ := a.parseDirective(s)
+	switch name {
+	case "EACH":
+		return name, nil
+	case "UNORDERED", "CONTAINS", "CONTAINS_ORDERED", "ONLY":
+		if arg == "" {
+			return name, nil
+		}
+		if name == "CONTAINS" {
+			return "", nil
+		}
+		return "", errors.New("array directives other than EACH do not take an argument")
+	}
+	return "", nil
+}
+
 func (a *Asserter) checkArrayUnordered(path string, act, exp []interface{}) {
 	a.tt.Helper()
 	if len(act) != len(exp) {
@@ -29,36 +77,213 @@ func (a *Asserter) checkArrayUnordered(path string, act, exp []interface{}) {
 		return
 	}
 
-	for i, actEl := range act {
+	actMatches, expMatches := a.matchElements(path, act, exp)
+	for i, match := range actMatches {
+		if match == unmatched {
+			a.reportUnmatchedElement(path, i, act[i], exp, expMatches)
+		}
+	}
+	for i, match := range expMatches {
+		if match == unmatched {
+			a.reportMissingElement(path, i, exp[i], "")
+		}
+	}
+}
+
+// reportUnmatchedElement reports the i-th actual element, which did not match
+// any expected element. Printing entire unmatched objects is rarely helpful,
+// so instead print the differences against the most similar expected element,
+// which is then considered matched.
+func (a *Asserter) reportUnmatchedElement(path string, i int, actEl interface{}, exp []interface{}, expMatches []int) {
+	a.tt.Helper()
+	j := a.closestElement(path, i, actEl, exp, expMatches)
+	if j == unmatched {
+		a.reportUnexpectedElement(path, i, actEl)
+		return
+	}
+	expMatches[j] = i
+	a.tt.Errorf("actual JSON at '%s[%d]' did not match any expected element, closest match was expected JSON at '%s[%d]' with differences:", path, i, path, j)
+	a.pathassertf(elementPath(path, i), serialize(actEl), serialize(exp[j]))
+}
+
+// closestElement returns the index of the yet unmatched expected element that
+// has the fewest differences to the i-th actual element. Only objects and
+// arrays are considered, as the differences between primitives are evident
+// from the elements themselves. Returns unmatched if there are no candidates.
+func (a *Asserter) closestElement(path string, i int, actEl interface{}, exp []interface{}, expMatches []int) int {
+	actType, _ := a.findType(serialize(actEl))
+	if actType != jsonObject && actType != jsonArray {
+		return unmatched
+	}
+	closest, fewestDifferences := unmatched, 0
+	for j, expEl := range exp {
+		if expMatches[j] != unmatched {
+			continue
+		}
+		if expType, _ := a.findType(serialize(expEl)); expType != actType {
+			continue
+		}
+		differences := a.countDifferences(elementPath(path, i), actEl, expEl)
+		if closest == unmatched || differences < fewestDifferences {
+			closest, fewestDifferences = j, differences
+		}
+	}
+	return closest
+}
+
+// checkArrayContains verifies that every expected element is present
+// somewhere in the actual array, ignoring any additional actual elements.
+// Each actual element may only be used to satisfy a single expected element.
+func (a *Asserter) checkArrayContains(path string, act, exp []interface{}) {
+	a.tt.Helper()
+	_, expMatches := a.matchElements(path, act, exp)
+	for i, match := range expMatches {
+		if match == unmatched {
+			a.reportMissingElement(path, i, exp[i], "")
+		}
+	}
+}
+
+// checkArrayContainsOrdered verifies that the expected elements appear in the
+// actual array in the same order, ignoring any additional actual elements.
+func (a *Asserter) checkArrayContainsOrdered(path string, act, exp []interface{}) {
+	a.tt.Helper()
+	next := 0
+	for i, expEl := range exp {
 		found := false
-		for _, expEl := range exp {
-			if a.deepEqual(actEl, expEl) {
-				found = true
+		for j := next; j < len(act) && !found; j++ {
+			var captures map[string]interface{}
+			if found, captures = a.match(elementPath(path, j), act[j], expEl); found {
+				a.keepCaptures(captures)
+				next = j + 1
 			}
 		}
 		if !found {
-			serializedEl := serialize(actEl)
-			if len(serializedEl) < maxMsgCharCount {
-				a.tt.Errorf("actual JSON at '%s[%d]' contained an unexpected element: %s", path, i, serializedEl)
-			} else {
-				a.tt.Errorf("actual JSON at '%s[%d]' contained an unexpected element:\n%s", path, i, serializedEl)
-			}
+			a.reportMissingElement(path, i, expEl, ", or was out of order")
 		}
 	}
+}
 
-	for i, expEl := range exp {
+// checkArrayOnly verifies that every actual element is equal to some
+// expected element, ignoring whether every expected element is present.
+func (a *Asserter) checkArrayOnly(path string, act, exp []interface{}) {
+	a.tt.Helper()
+	for i, actEl := range act {
 		found := false
-		for _, actEl := range act {
-			found = found || a.deepEqual(actEl, expEl)
+		for j := 0; j < len(exp) && !found; j++ {
+			var captures map[string]interface{}
+			if found, captures = a.match(elementPath(path, i), actEl, exp[j]); found {
+				a.keepCaptures(captures)
+			}
 		}
 		if !found {
-			serializedEl := serialize(expEl)
-			if len(serializedEl) < maxMsgCharCount {
-				a.tt.Errorf("expected JSON at '%s[%d]': %s was missing from actual payload", path, i, serializedEl)
-			} else {
-				a.tt.Errorf("expected JSON at '%s[%d]':\n%s\nwas missing from actual payload", path, i, serializedEl)
+			a.reportUnexpectedElement(path, i, actEl)
+		}
+	}
+}
+
+// checkArrayEach verifies every actual element against the single template
+// following the "<<EACH>>" directive, which may optionally specify the allowed
+// length of the array, e.g. "<<EACH:1..10>>" or "<<EACH:>=1>>".
+func (a *Asserter) checkArrayEach(path string, act, exp []interface{}) {
+	a.tt.Helper()
+	directive, _ := exp[0].(string)
+	if len(exp) != 2 { //nolint:mnd // the directive and the template
+		a.invalidDirective(path, directive, errors.New("must be followed by exactly one element"))
+		return
+	}
+	if _, arg, _ := a.parseDirective(directive); arg != "" && !a.checkLength(path, directive, arg, jsonArray, len(act)) {
+		return
+	}
+	for i := range act {
+		a.pathassertf(elementPath(path, i), serialize(act[i]), serialize(exp[1]))
+	}
+}
+
+const unmatched = -1
+
+// matchElements pairs up equal actual and expected elements such that each
+// element is part of at most one pair, and the number of pairs is as large as
+// possible. This is a maximum bipartite matching, found with Kuhn's algorithm.
+// The returned slices hold the index of the matching element in the other
+// array, or unmatched. Any values captured by the matched pairs are kept.
+func (a *Asserter) matchElements(path string, act, exp []interface{}) (actMatches, expMatches []int) {
+	equal, captures := a.equalElements(path, act, exp)
+	actMatches, expMatches = unmatchedIndices(len(act)), unmatchedIndices(len(exp))
+
+	// augment attempts to find an expected element for the i-th actual
+	// element, potentially by re-assigning other actual elements to other
+	// expected elements.
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j := range exp {
+			if !equal[i][j] || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if expMatches[j] == unmatched || augment(expMatches[j], visited) {
+				actMatches[i], expMatches[j] = j, i
+				return true
 			}
 		}
+		return false
+	}
+	for i := range act {
+		augment(i, make([]bool, len(exp)))
+	}
+	a.keepMatchedCaptures(actMatches, captures)
+	return actMatches, expMatches
+}
+
+// keepMatchedCaptures keeps the values captured by the matched pairs.
+func (a *Asserter) keepMatchedCaptures(actMatches []int, captures [][]map[string]interface{}) {
+	for i, j := range actMatches {
+		if j != unmatched {
+			a.keepCaptures(captures[i][j])
+		}
+	}
+}
+
+// equalElements returns a matrix of whether the i-th actual element is equal
+// to the j-th expected element, along with the values that each pair would
+// capture.
+func (a *Asserter) equalElements(path string, act, exp []interface{}) ([][]bool, [][]map[string]interface{}) {
+	equal, captures := make([][]bool, len(act)), make([][]map[string]interface{}, len(act))
+	for i := range act {
+		equal[i], captures[i] = make([]bool, len(exp)), make([]map[string]interface{}, len(exp))
+		for j := range exp {
+			equal[i][j], captures[i][j] = a.match(elementPath(path, i), act[i], exp[j])
+		}
+	}
+	return equal, captures
+}
+
+// unmatchedIndices returns n indices that have not been matched yet.
+func unmatchedIndices(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = unmatched
+	}
+	return indices
+}
+
+func (a *Asserter) reportUnexpectedElement(path string, i int, actEl interface{}) {
+	a.tt.Helper()
+	serializedEl := serialize(actEl)
+	if len(serializedEl) < maxMsgCharCount {
+		a.tt.Errorf("actual JSON at '%s[%d]' contained an unexpected element: %s", path, i, serializedEl)
+	} else {
+		a.tt.Errorf("actual JSON at '%s[%d]' contained an unexpected element:\n%s", path, i, serializedEl)
+	}
+}
+
+func (a *Asserter) reportMissingElement(path string, i int, expEl interface{}, suffix string) {
+	a.tt.Helper()
+	serializedEl := serialize(expEl)
+	if len(serializedEl) < maxMsgCharCount {
+		a.tt.Errorf("expected JSON at '%s[%d]': %s was missing from actual payload%s", path, i, serializedEl, suffix)
+	} else {
+		a.tt.Errorf("expected JSON at '%s[%d]':\n%s\nwas missing from actual payload%s", path, i, serializedEl, suffix)
 	}
 }
 
@@ -75,15 +300,19 @@ func (a *Asserter) checkArrayOrdered(path string, act, exp []interface{}) {
 		return
 	}
 	for i := range act {
-		a.pathassertf(path+fmt.Sprintf("[%d]", i), serialize(act[i]), serialize(exp[i]))
+		a.pathassertf(elementPath(path, i), serialize(act[i]), serialize(exp[i]))
 	}
 }
 
+func elementPath(path string, i int) string {
+	return path + fmt.Sprintf("[%d]", i)
+}
+
 func extractArray(s string) ([]interface{}, bool) {
 	s = strings.TrimSpace(s)
 	if s == "" {
 		return nil, false
 	}
 	var arr []interface{}
-	return arr, json.Unmarshal([]byte(s), &arr) == nil
+	return arr, unmarshal(s, &arr) == nil
 }
@@ -0,0 +1,83 @@
+package jsonassert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bounds is a range of allowed numbers, written as either an exact number,
+// e.g. "3", a comparison, e.g. ">=1", or an inclusive range, e.g. "1..10".
+// Either end of a range may be omitted, e.g. "1..", in which case that end is
+// unbounded.
+type bounds struct {
+	min, max                   float64
+	hasMin, hasMax             bool
+	minExclusive, maxExclusive bool
+}
+
+func parseBounds(s string) (bounds, error) {
+	s = strings.TrimSpace(s)
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if operand, ok := strings.CutPrefix(s, op); ok {
+			n, err := parseBound(operand)
+			if err != nil {
+				return bounds{}, err
+			}
+			if op[0] == '>' {
+				return bounds{min: n, hasMin: true, minExclusive: op == ">"}, nil
+			}
+			return bounds{max: n, hasMax: true, maxExclusive: op == "<"}, nil
+		}
+	}
+	lower, upper, isRange := strings.Cut(s, "..")
+	if !isRange {
+		n, err := parseBound(s)
+		return bounds{min: n, max: n, hasMin: true, hasMax: true}, err
+	}
+	return parseRange(strings.TrimSpace(lower), strings.TrimSpace(upper))
+}
+
+// parseRange parses the bounds of an inclusive range, e.g. "1..10", either of
+// which may be empty.
+func parseRange(lower, upper string) (bounds, error) {
+	var (
+		b   bounds
+		err error
+	)
+	if lower != "" {
+		if b.min, err = parseBound(lower); err != nil {
+			return bounds{}, err
+		}
+		b.hasMin = true
+	}
+	if upper != "" {
+		if b.max, err = parseBound(upper); err != nil {
+			return bounds{}, err
+		}
+		b.hasMax = true
+	}
+	if b.hasMin && b.hasMax && b.min > b.max {
+		return bounds{}, fmt.Errorf("lower bound '%s' is greater than upper bound '%s'", lower, upper)
+	}
+	return b, nil
+}
+
+func parseBound(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	n, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse '%s' as a number", s)
+	}
+	return n, nil
+}
+
+func (b bounds) contains(f float64) bool {
+	if b.hasMin && (f < b.min || b.minExclusive && f == b.min) {
+		return false
+	}
+	if b.hasMax && (f > b.max || b.maxExclusive && f == b.max) {
+		return false
+	}
+	return true
+}
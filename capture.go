@@ -0,0 +1,203 @@
+package jsonassert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// captureStore holds the values recorded by "<<CAPTURE:name>>" directives.
+// It is shared between all copies of an Asserter.
+type captureStore struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+func (c *captureStore) get(name string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	val, ok := c.values[name]
+	return val, ok
+}
+
+func (c *captureStore) set(name string, val interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[name] = val
+}
+
+/*
+Captured returns the value that was recorded by a "<<CAPTURE:name>>"
+directive in a previous assertion made by this Asserter. This is useful when
+the value is needed for anything other than another assertion, e.g. to build
+the URL of a subsequent request. Strings are returned as string, numbers as
+json.Number, booleans as bool, objects as map[string]interface{}, and arrays as
+[]interface{}.
+The boolean return value is false if no value has been captured by that name.
+*/
+func (a *Asserter) Captured(name string) (interface{}, bool) {
+	return a.captures.get(name)
+}
+
+// capture records the actual value for later use in "<<REF:name>>" directives.
+func (a *Asserter) capture(path, act, directive, name string) {
+	a.tt.Helper()
+	if name == "" {
+		a.invalidDirective(path, directive, errors.New("a name is required"))
+		return
+	}
+	var val interface{}
+	_ = unmarshal(act, &val) // Known to be valid JSON by now.
+	a.keepCaptures(map[string]interface{}{name: val})
+}
+
+// keepCaptures records the given captured values. Attempts to match e.g.
+// unordered array elements against their potential counterparts must not
+// overwrite values captured elsewhere, so these are only kept as pending
+// until the caller settles on a match.
+func (a *Asserter) keepCaptures(captures map[string]interface{}) {
+	for name, val := range captures {
+		if a.dryRun {
+			a.pendingCaptures[name] = val
+		} else {
+			a.captures.set(name, val)
+		}
+	}
+}
+
+// captured returns the value recorded by a "<<CAPTURE:name>>" directive,
+// including those that are still pending.
+func (a *Asserter) captured(name string) (interface{}, bool) {
+	if val, ok := a.pendingCaptures[name]; ok {
+		return val, true
+	}
+	return a.captures.get(name)
+}
+
+// checkReference verifies that the actual value is equal to a value that was
+// previously recorded by a "<<CAPTURE:name>>" directive.
+func (a *Asserter) checkReference(path, act, directive, name string) {
+	a.tt.Helper()
+	captured, ok := a.captured(name)
+	if !ok {
+		a.invalidDirective(path, directive, fmt.Errorf("no value has been captured as '%s'", name))
+		return
+	}
+	var val interface{}
+	_ = unmarshal(act, &val) // Known to be valid JSON by now.
+	if !a.sameValues(path, val, captured) {
+		a.tt.Errorf("expected value at '%s' to be %s, as captured by '%s', but was %s", path, serialize(captured), name, strings.TrimSpace(act))
+	}
+}
+
+// containsCapture reports whether the template contains any "<<CAPTURE:name>>"
+// directives.
+func (a *Asserter) containsCapture(template interface{}) bool {
+	var children []interface{}
+	switch template := template.(type) {
+	case string:
+		name, _, _ := a.parseDirective(template)
+		return name == "CAPTURE"
+	case map[string]interface{}:
+		for _, val := range template {
+			children = append(children, val)
+		}
+	case []interface{}:
+		children = template
+	}
+	for _, child := range children {
+		if a.containsCapture(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCaptures records the values of any "<<CAPTURE:name>>" directives in
+// the expected JSON before the assertion is made, so that "<<REF:name>>"
+// directives that are checked while matching up e.g. unordered array elements
+// may refer to them regardless of where they appear in the same payload.
+// Only CAPTURE directives are considered, so no other checks are made twice.
+// Values in arrays that start with a directive such as "<<UNORDERED>>", and in
+// alternatives such as {"<<ONEOF>>": [...]}, are only captured once their
+// counterpart is known during the assertion itself.
+func (a *Asserter) recordCaptures(act, exp string) {
+	if !strings.Contains(exp, a.directivePrefix+"CAPTURE:") {
+		return
+	}
+	var actVal, expVal interface{}
+	if unmarshal(act, &actVal) != nil || unmarshal(exp, &expVal) != nil {
+		return // Reported by the assertion itself.
+	}
+	a.collectCaptures(actVal, expVal)
+}
+
+func (a *Asserter) collectCaptures(act, exp interface{}) {
+	switch exp := exp.(type) {
+	case string:
+		a.collectDirectiveCaptures(act, exp)
+	case map[string]interface{}:
+		a.collectObjectCaptures(act, exp)
+	case []interface{}:
+		a.collectArrayCaptures(act, exp)
+	}
+}
+
+func (a *Asserter) collectDirectiveCaptures(act interface{}, exp string) {
+	switch name, arg, _ := a.parseDirective(exp); name {
+	case "CAPTURE":
+		if arg != "" {
+			a.captures.set(arg, act)
+		}
+	case "JSON":
+		var template interface{}
+		if unmarshal(arg, &template) == nil {
+			a.collectEmbeddedCaptures(act, template)
+		}
+	}
+}
+
+func (a *Asserter) collectObjectCaptures(act interface{}, exp map[string]interface{}) {
+	actObject, _ := act.(map[string]interface{})
+	for key, val := range exp {
+		name, arg, isDirective := a.parseDirective(key)
+		switch {
+		case name == "JSON_STRING" && len(exp) == 1:
+			a.collectEmbeddedCaptures(act, val)
+			continue
+		case name == "LITERAL":
+			key = arg
+		case isDirective:
+			continue
+		}
+		if actVal, found := actObject[key]; found {
+			a.collectCaptures(actVal, val)
+		}
+	}
+}
+
+func (a *Asserter) collectArrayCaptures(act interface{}, exp []interface{}) {
+	actArray, _ := act.([]interface{})
+	if len(exp) == 0 {
+		return
+	}
+	if first, isString := exp[0].(string); isString {
+		if _, _, isDirective := a.parseDirective(first); isDirective {
+			return
+		}
+	}
+	for i := 0; i < len(exp) && i < len(actArray); i++ {
+		a.collectCaptures(actArray[i], exp[i])
+	}
+}
+
+// collectEmbeddedCaptures collects the captures of a template for JSON that
+// is embedded in the actual string.
+func (a *Asserter) collectEmbeddedCaptures(act, template interface{}) {
+	actString, isString := act.(string)
+	var embedded interface{}
+	if isString && unmarshal(actString, &embedded) == nil {
+		a.collectCaptures(embedded, template)
+	}
+}
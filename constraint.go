@@ -0,0 +1,195 @@
+package jsonassert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// checkArrayConstraints checks the leading directives of the expected array
+// that constrain the actual array as a whole, i.e. "<<SORTED>>" and
+// "<<UNIQUE>>", and returns the remaining expected elements.
+func (a *Asserter) checkArrayConstraints(path string, act, exp []interface{}) []interface{} {
+	a.tt.Helper()
+	for len(exp) > 0 {
+		directive, _ := exp[0].(string)
+		name, arg, ok := a.parseDirective(directive)
+		if !ok {
+			return exp
+		}
+		switch name {
+		case "SORTED":
+			a.checkSorted(path, act, directive, arg)
+		case "UNIQUE":
+			a.checkUnique(path, act, directive, arg)
+		default:
+			return exp
+		}
+		exp = exp[1:]
+	}
+	return exp
+}
+
+// checkSorted verifies that the actual elements, or the values found at the
+// given path within each element, are in order. The argument of the
+// directive is e.g. "asc", "desc", or "desc,by=$.createdAt".
+func (a *Asserter) checkSorted(path string, act []interface{}, directive, arg string) {
+	a.tt.Helper()
+	order, by, err := parseSortOptions(arg)
+	if err != nil {
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	keys, ok := a.elementKeys(path, act, directive, by)
+	if !ok {
+		return
+	}
+	for i := 1; i < len(keys); i++ {
+		cmp, comparable := a.compareSortKeys(keys[i-1], keys[i])
+		if !comparable {
+			a.tt.Errorf("expected values at '%s' and '%s' to both be numbers or strings in order to be sorted, but were %s and %s",
+				keyPath(path, i-1, by), keyPath(path, i, by), serialize(keys[i-1]), serialize(keys[i]))
+			return
+		}
+		if (order == "ascending" && cmp > 0) || (order == "descending" && cmp < 0) {
+			a.tt.Errorf("expected array at '%s' to be sorted in %s order, but '%s' (%s) came before '%s' (%s)",
+				path, order, keyPath(path, i-1, by), serialize(keys[i-1]), keyPath(path, i, by), serialize(keys[i]))
+			return
+		}
+	}
+}
+
+// parseSortOptions parses the argument of a "<<SORTED>>" directive into
+// the order, "ascending" by default, and the path to sort by within each
+// element, "$" by default.
+func parseSortOptions(s string) (order, by string, err error) {
+	order, by = "ascending", "$"
+	if s == "" {
+		return order, by, nil
+	}
+	for _, opt := range strings.Split(s, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "asc":
+			order = "ascending"
+		case "desc":
+			order = "descending"
+		case "by":
+			by = val
+		default:
+			return "", "", fmt.Errorf("unknown option '%s'", key)
+		}
+	}
+	return order, by, nil
+}
+
+// checkUnique verifies that no two actual elements, or the values found at
+// the given path within each element, are equal. The argument of the
+// directive is e.g. "by=$.id", or just "$.id".
+func (a *Asserter) checkUnique(path string, act []interface{}, directive, arg string) {
+	a.tt.Helper()
+	by, err := parseUniqueOptions(arg)
+	if err != nil {
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	keys, ok := a.elementKeys(path, act, directive, by)
+	if !ok {
+		return
+	}
+	for j := range keys {
+		for i := 0; i < j; i++ {
+			if a.sameValues(keyPath(path, j, by), keys[j], keys[i]) {
+				a.tt.Errorf("expected unique values in array at '%s', but '%s' was the same as '%s': %s",
+					path, keyPath(path, j, by), keyPath(path, i, by), serialize(keys[j]))
+				break
+			}
+		}
+	}
+}
+
+// parseUniqueOptions parses the argument of a "<<UNIQUE>>" directive into the
+// path to compare within each element, "$" by default. Unlike for
+// "<<SORTED>>", the path may also be given without "by=".
+func parseUniqueOptions(s string) (by string, err error) {
+	by = "$"
+	if s == "" {
+		return by, nil
+	}
+	if !strings.Contains(s, "=") {
+		return s, nil
+	}
+	for _, opt := range strings.Split(s, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		if key != "by" {
+			return "", fmt.Errorf("unknown option '%s'", key)
+		}
+		by = val
+	}
+	return by, nil
+}
+
+// elementKeys resolves the given path, e.g. "$.id", within each element.
+// Returns false if any of the values could not be found, having reported why.
+func (a *Asserter) elementKeys(path string, act []interface{}, directive, by string) ([]interface{}, bool) {
+	a.tt.Helper()
+	keys := make([]interface{}, len(act))
+	for i, el := range act {
+		key, err := resolvePath(el, by)
+		var missing missingValueError
+		switch {
+		case errors.As(err, &missing):
+			a.tt.Errorf("expected element at '%s' to have a value at '%s', but there was %s", elementPath(path, i), by, err.Error())
+			return nil, false
+		case err != nil:
+			a.invalidDirective(path, directive, err)
+			return nil, false
+		}
+		keys[i] = key
+	}
+	return keys, true
+}
+
+// compareSortKeys compares two numbers or two strings. The second return
+// value is false if the values are of any other, or different, types.
+func (a *Asserter) compareSortKeys(x, y interface{}) (int, bool) {
+	switch x := x.(type) {
+	case json.Number:
+		y, isNumber := y.(json.Number)
+		return a.compareNumbers(x, y), isNumber
+	case string:
+		y, isString := y.(string)
+		return strings.Compare(x, y), isString
+	}
+	return 0, false
+}
+
+// compareNumbers compares two numbers, exactly if the WithPreciseNumbers
+// Option was given.
+func (a *Asserter) compareNumbers(x, y json.Number) int {
+	if a.preciseNumbers {
+		xr, _ := new(big.Rat).SetString(x.String())
+		yr, _ := new(big.Rat).SetString(y.String())
+		if xr == nil || yr == nil {
+			return 0
+		}
+		return xr.Cmp(yr)
+	}
+	xf, _ := x.Float64()
+	yf, _ := y.Float64()
+	switch {
+	case xf < yf:
+		return -1
+	case xf > yf:
+		return 1
+	}
+	return 0
+}
+
+// keyPath returns the path of the value found at the given path within the
+// i-th element, e.g. "$.items[1].createdAt".
+func keyPath(path string, i int, by string) string {
+	return elementPath(path, i) + strings.TrimPrefix(by, "$")
+}
@@ -2,7 +2,9 @@ package jsonassert
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -15,22 +17,22 @@ func (a *Asserter) pathassertf(path, act, exp string) {
 	if act == exp {
 		return
 	}
-	actType, err := findType(act)
+	actType, err := a.findType(act)
 	if err != nil {
 		a.tt.Errorf("'actual' JSON is not valid JSON: " + err.Error())
 		return
 	}
-	expType, err := findType(exp)
+	expType, err := a.findType(exp)
 	if err != nil {
 		a.tt.Errorf("'expected' JSON is not valid JSON: " + err.Error())
 		return
 	}
 
-	// If we're only caring about the presence of the key, then don't bother checking any further
-	if expPresence, _ := extractString(exp); expPresence == "<<PRESENCE>>" {
-		if actType == jsonNull {
-			a.tt.Errorf(`expected the presence of any value at '%s', but was absent`, path)
-		}
+	if expDirective, ok := extractString(exp); ok && a.checkDirective(path, act, actType, expDirective) {
+		return
+	}
+
+	if expType == jsonObject && a.checkObjectDirective(path, act, actType, exp) {
 		return
 	}
 
@@ -44,9 +46,7 @@ func (a *Asserter) pathassertf(path, act, exp string) {
 		expBool, _ := extractBoolean(exp)
 		a.checkBoolean(path, actBool, expBool)
 	case jsonNumber:
-		actNumber, _ := extractNumber(act)
-		expNumber, _ := extractNumber(exp)
-		a.checkNumber(path, actNumber, expNumber)
+		a.checkNumber(path, strings.TrimSpace(act), strings.TrimSpace(exp))
 	case jsonString:
 		actString, _ := extractString(act)
 		expString, _ := extractString(exp)
@@ -69,6 +69,21 @@ func serialize(a interface{}) string {
 	return string(bytes)
 }
 
+// unmarshal works like json.Unmarshal, except that numbers are decoded as
+// json.Number in order to preserve their original representation, e.g. when
+// serializing them again.
+func unmarshal(s string, v interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unexpected data after top-level value in %s", s)
+	}
+	return nil
+}
+
 type jsonType string
 
 const (
@@ -81,12 +96,12 @@ const (
 	jsonTypeUnknown jsonType = "unknown"
 )
 
-func findType(j string) (jsonType, error) {
+func (a *Asserter) findType(j string) (jsonType, error) {
 	j = strings.TrimSpace(j)
 	if _, ok := extractString(j); ok {
 		return jsonString, nil
 	}
-	if _, ok := extractNumber(j); ok {
+	if a.isNumber(j) {
 		return jsonNumber, nil
 	}
 	if j == "null" {
@@ -104,6 +119,16 @@ func findType(j string) (jsonType, error) {
 	return jsonTypeUnknown, fmt.Errorf(`unable to identify JSON type of "%s"`, j)
 }
 
+// isNumber reports whether j is a JSON number. Numbers are compared exactly in
+// precise mode, so they need not fit in a float64 in that case, e.g. 2e400.
+func (a *Asserter) isNumber(j string) bool {
+	if a.preciseNumbers {
+		return j != "" && (j[0] == '-' || j[0] >= '0' && j[0] <= '9') && json.Valid([]byte(j))
+	}
+	_, ok := extractNumber(j)
+	return ok
+}
+
 // *testing.T has a Helper() func that allow testing tools like this package to
 // ignore their own frames when calling Errorf on *testing.T instances.
 // This interface is here to avoid breaking backwards compatibility in terms of
@@ -0,0 +1,204 @@
+package jsonassert
+
+import "strings"
+
+const (
+	defaultDirectivePrefix = "<<"
+	defaultDirectiveSuffix = ">>"
+)
+
+// parseDirective splits a directive such as "<<PRESENCE>>" or "<<NAME:arg>>"
+// into its name and (potentially empty) argument. The boolean return value
+// indicates whether s was a directive at all. The "<<" and ">>" delimiters
+// may be changed with the WithDirectiveDelimiters Option.
+func (a *Asserter) parseDirective(s string) (name, arg string, ok bool) {
+	if len(s) < len(a.directivePrefix)+len(a.directiveSuffix) {
+		return "", "", false
+	}
+	if !strings.HasPrefix(s, a.directivePrefix) || !strings.HasSuffix(s, a.directiveSuffix) {
+		return "", "", false
+	}
+	name, arg, _ = strings.Cut(s[len(a.directivePrefix):len(s)-len(a.directiveSuffix)], ":")
+	return name, arg, true
+}
+
+/*
+Escape returns s in such a way that it will not be interpreted as a
+directive when used as an expected string value or object key, e.g.
+
+	ja.Assertf(`{"template": "<<PRESENCE>>"}`, `{"template": "%s"}`, ja.Escape("<<PRESENCE>>"))
+
+Strings that would not be interpreted as a directive are returned as is, so
+Escape is safe to use for any string inserted with a format argument.
+*/
+func (a *Asserter) Escape(s string) string {
+	if _, _, ok := a.parseDirective(s); !ok {
+		return s
+	}
+	return a.directivePrefix + "LITERAL:" + s + a.directiveSuffix
+}
+
+// checkDirective makes assertions against directives that may stand in for
+// any type of value in the expected JSON, e.g. "<<PRESENCE>>".
+// Returns false if exp is not such a directive, in which case the expected
+// value should be compared as regular JSON.
+func (a *Asserter) checkDirective(path, act string, actType jsonType, exp string) bool {
+	a.tt.Helper()
+	name, arg, ok := a.parseDirective(exp)
+	if !ok {
+		return false
+	}
+	if a.checkPresenceDirective(path, act, actType, name) ||
+		a.checkValueDirective(path, act, actType, exp, name, arg) ||
+		a.checkReferenceDirective(path, act, exp, name, arg) {
+		return true
+	}
+	if isStringDirective(name) {
+		// Handled by checkString, once we know the types match.
+		return !a.checkStringArgument(path, exp, arg)
+	}
+	return a.checkFormat(path, act, name) || a.checkMatcher(path, act, name, arg)
+}
+
+// checkPresenceDirective handles the directives that only care about whether,
+// or what type of, value is present, e.g. "<<PRESENCE>>" or "<<STRING>>".
+func (a *Asserter) checkPresenceDirective(path, act string, actType jsonType, name string) bool {
+	a.tt.Helper()
+	switch name {
+	case "PRESENCE":
+		if actType == jsonNull {
+			a.tt.Errorf(`expected the presence of any value at '%s', but was absent`, path)
+		}
+		return true
+	case "ANY", "OPTIONAL":
+		// Missing object keys are handled in checkObject, so if we get this
+		// far then there's some value present, which is all we need.
+		return true
+	case "ABSENT":
+		a.tt.Errorf(`expected the absence of any value at '%s', but was %s`, path, strings.TrimSpace(act))
+		return true
+	case "STRING", "BOOLEAN", "OBJECT", "ARRAY":
+		// If we're only caring about the presence of a value of a given type,
+		// then don't bother checking any further.
+		if expType := jsonType(strings.ToLower(name)); actType != expType {
+			a.tt.Errorf("actual JSON (%s) and expected JSON (%s) were of different types at '%s'", actType, expType, path)
+		}
+		return true
+	}
+	return false
+}
+
+// checkValueDirective handles the directives that constrain the value itself,
+// e.g. "<<LEN:3>>" or "<<RANGE:1..10>>".
+func (a *Asserter) checkValueDirective(path, act string, actType jsonType, exp, name, arg string) bool {
+	a.tt.Helper()
+	switch name {
+	case "LITERAL":
+		a.checkLiteralDirective(path, act, actType, arg)
+	case "LEN", "NONEMPTY":
+		a.checkLengthDirective(path, act, actType, exp, name, arg)
+	case "JSON":
+		a.checkEmbeddedJSON(path, act, actType, exp, arg)
+	case "ONEOF":
+		a.checkInlineOneOf(path, act, arg)
+	case "NOT":
+		a.checkInlineNot(path, act, arg)
+	case "NUMBER", "RANGE", "INT", "APPROX":
+		a.checkNumberDirective(path, act, actType, exp, name, arg)
+	case "TIME":
+		a.checkTime(path, act, actType, exp, arg)
+	default:
+		return false
+	}
+	return true
+}
+
+// checkReferenceDirective handles the directives that refer to other values,
+// either in the same payload or in previous assertions.
+func (a *Asserter) checkReferenceDirective(path, act, exp, name, arg string) bool {
+	a.tt.Helper()
+	switch name {
+	case "CAPTURE":
+		a.capture(path, act, exp, arg)
+	case "REF":
+		// Values captured in e.g. unordered arrays are only known once
+		// their elements have been matched up.
+		a.after(func() { a.checkReference(path, act, exp, arg) })
+	case "SAME_AS":
+		// The other value may not have been checked yet, so wait until the
+		// rest of the assertion is done.
+		a.after(func() { a.checkSameAs(path, act, exp, arg) })
+	default:
+		return false
+	}
+	return true
+}
+
+func (a *Asserter) checkLiteralDirective(path, act string, actType jsonType, literal string) {
+	a.tt.Helper()
+	if actString, isString := extractString(act); isString {
+		a.checkLiteralString(path, actString, literal)
+	} else {
+		a.tt.Errorf("actual JSON (%s) and expected JSON (%s) were of different types at '%s'", actType, jsonString, path)
+	}
+}
+
+func (a *Asserter) checkLengthDirective(path, act string, actType jsonType, directive, name, constraint string) {
+	a.tt.Helper()
+	if name == "NONEMPTY" {
+		constraint = ">=1"
+	}
+	if length, hasLength := lengthOf(act, actType); hasLength {
+		a.checkLength(path, directive, constraint, actType, length)
+	} else {
+		a.tt.Errorf("expected array, string, or object at '%s' but was %s", path, actType)
+	}
+}
+
+// checkObjectDirective makes assertions against directives that take the
+// form of an object with a single directive key, the value of which is the
+// directive's argument, e.g. {"<<JSON_STRING>>": {"id": 1}}.
+// Returns false if exp is not such a directive.
+func (a *Asserter) checkObjectDirective(path, act string, actType jsonType, exp string) bool {
+	a.tt.Helper()
+	expObject, _ := extractObject(exp)
+	if len(expObject) != 1 {
+		return false
+	}
+	for key, val := range expObject {
+		name, arg, ok := a.parseDirective(key)
+		if !ok || arg != "" {
+			return false
+		}
+		switch name {
+		case "JSON_STRING":
+			a.checkEmbeddedJSON(path, act, actType, key, serialize(val))
+			return true
+		case "ONEOF":
+			a.checkTemplateOneOf(path, act, key, val)
+			return true
+		case "NOT":
+			a.checkTemplateNot(path, act, key, val)
+			return true
+		}
+	}
+	return false
+}
+
+// parseInlineValue interprets a value given as part of a directive, e.g. each
+// of the values in "<<ONEOF:1,null,active>>". Values that are valid JSON are
+// used as is, and any other values are taken to be strings.
+func parseInlineValue(s string) interface{} {
+	var val interface{}
+	if err := unmarshal(s, &val); err != nil {
+		return s
+	}
+	return val
+}
+
+// invalidDirective reports a problem with the expected JSON itself, as opposed
+// to a discrepancy between the actual and the expected JSON.
+func (a *Asserter) invalidDirective(path, directive string, err error) {
+	a.tt.Helper()
+	a.tt.Errorf("'expected' JSON contained an invalid directive '%s' at '%s': %s", directive, path, err.Error())
+}
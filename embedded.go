@@ -0,0 +1,22 @@
+package jsonassert
+
+// checkEmbeddedJSON verifies that the actual value is a string containing
+// JSON, which in turn matches the given expected JSON. Differences within the
+// embedded JSON are reported with paths such as "$.payload<json>.id".
+func (a *Asserter) checkEmbeddedJSON(path, act string, actType jsonType, directive, exp string) {
+	a.tt.Helper()
+	if _, err := a.findType(exp); err != nil {
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	actString, isString := extractString(act)
+	if !isString {
+		a.tt.Errorf("actual JSON (%s) and expected JSON (%s) were of different types at '%s'", actType, jsonString, path)
+		return
+	}
+	if _, err := a.findType(actString); err != nil {
+		a.tt.Errorf("expected string at '%s' to contain JSON but was '%s'", path, actString)
+		return
+	}
+	a.pathassertf(path+"<json>", actString, exp)
+}
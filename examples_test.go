@@ -2,6 +2,7 @@ package jsonassert_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kinbiko/jsonassert"
 )
@@ -57,3 +58,20 @@ func ExampleAsserter_Assertf_unorderedArray() {
 	// actual JSON at '$[0]' contained an unexpected element: "zero"
 	// expected JSON at '$[2]': "three" was missing from actual payload
 }
+
+func ExampleAsserter_RegisterMatcher() {
+	ja := jsonassert.New(t)
+	ja.RegisterMatcher("COUNTRY", func(_ string, actual interface{}) error {
+		code, ok := actual.(string)
+		if !ok || len(code) != 2 || strings.ToUpper(code) != code {
+			return fmt.Errorf("%v is not an ISO 3166-1 alpha-2 country code", actual)
+		}
+		return nil
+	})
+	ja.Assertf(
+		`[{"name": "Jayne Cobb", "country": "US"}, {"name": "Kaylee Frye", "country": "Earth"}]`,
+		`["<<EACH>>", {"name": "<<STRING>>", "country": "<<COUNTRY>>"}]`,
+	)
+	// output:
+	// expected COUNTRY at '$[1].country': Earth is not an ISO 3166-1 alpha-2 country code
+}
@@ -29,6 +29,58 @@ will verify that the UUID field is present, but does not check its actual value.
 You may use "<<PRESENCE>>" against any type of value. The only exception is null, which
 will result in an assertion failure.
 
+Use "<<ANY>>" to also accept null, "<<OPTIONAL>>" to additionally accept the
+key being missing, and "<<ABSENT>>" to require that the key is missing.
+
+Values may be recorded with "<<CAPTURE:name>>" and referred to with
+"<<REF:name>>", either in the same payload or in later assertions made by the
+same Asserter:
+
+	ja.Assertf(`{"id": "abc"}`, `{"id": "<<CAPTURE:userId>>"}`)
+	ja.Assertf(`{"user": {"id": "abc"}}`, `{"user": {"id": "<<REF:userId>>"}}`)
+
+Two values in the same payload may be required to be equal with
+"<<SAME_AS:path>>", where path refers to the other value, e.g. "$.data.id":
+
+	ja.Assertf(`{"id": "abc", "self": {"id": "abc"}}`, `{"id": "<<STRING>>", "self": {"id": "<<SAME_AS:$.id>>"}}`)
+
+If you also care about the type of the value, you may use one of "<<STRING>>",
+"<<NUMBER>>", "<<BOOLEAN>>", "<<OBJECT>>", or "<<ARRAY>>" instead:
+
+	ja.Assertf(`{"id": 1234}`, `{"id":"<<NUMBER>>"}`)
+
+Strings containing serialized JSON may be checked against an expected JSON
+value wrapped in an object with a single "<<JSON_STRING>>" key:
+
+	ja.Assertf(`{"payload": "{\"id\": 1}"}`, `{"payload": {"<<JSON_STRING>>": {"id": 1}}}`)
+
+Values may be required to be one of several values with e.g.
+"<<ONEOF:pending,active,closed>>", or to match one of several templates with
+{"<<ONEOF>>": [...]}.
+
+Similarly, "<<NOT:null>>" and {"<<NOT>>": ...} verify that a value is not the
+given value, or does not match the given template.
+
+Numbers may be checked against a range with e.g. "<<NUMBER:>0>>",
+"<<RANGE:1..100>>", "<<INT>>", or "<<APPROX:3.14±0.01>>".
+
+Strings may be matched against a regular expression with the "<<REGEX:pattern>>"
+directive:
+
+	ja.Assertf(`{"id": "abc-123"}`, `{"id":"<<REGEX:^[a-z]+-[0-9]+$>>"}`)
+
+Simpler checks are available with "<<PREFIX:s>>", "<<SUFFIX:s>>",
+"<<CONTAINS:s>>", "<<IEQUALS:s>>" (case-insensitive equality), and
+"<<STRLEN:n>>".
+
+Common string formats can be verified with "<<UUID>>", "<<RFC3339>>",
+"<<DATE>>", "<<EMAIL>>", "<<URI>>", "<<IP>>", "<<IPV4>>", "<<IPV6>>", and
+"<<BASE64>>".
+
+Timestamps may be required to be close to the current time, or to another time
+given with "at", with e.g. "<<TIME:within=5s>>" or
+"<<TIME:UNIX_MS,within=1m,at=2024-01-01T00:00:00Z>>".
+
 If you don't know / care about the order of the elements in an array in your
 payload, you can ignore the ordering:
 
@@ -37,6 +89,36 @@ payload, you can ignore the ordering:
 
 The above will verify that "foo", "bar", and "baz" are exactly the elements in
 the payload, but will ignore the order in which they appear.
+
+Similarly, "<<CONTAINS>>" verifies that the listed elements are present in any
+order, "<<CONTAINS_ORDERED>>" that they are present in the listed order, and
+"<<ONLY>>" that the payload contains no elements other than the listed ones.
+
+Arrays may also be required to be sorted or to have unique elements with
+leading "<<SORTED:asc>>", "<<SORTED:desc,by=$.createdAt>>", "<<UNIQUE>>", or
+"<<UNIQUE:by=$.id>>" elements.
+
+If all elements of an array share the same shape, you may use "<<EACH>>" to
+verify every element against a single template:
+
+	ja.Assertf(`[{"id": 1}, {"id": 2}]`, `["<<EACH>>", {"id": "<<NUMBER>>"}]`)
+
+If you only care about the length of an array, string, or object, you may use
+"<<LEN:n>>", where n is e.g. "3", ">=1", or "1..10", or "<<NONEMPTY>>":
+
+	ja.Assertf(`{"items": [1, 2, 3]}`, `{"items": "<<LEN:>=1>>"}`)
+
+If you only care about some of the keys in an object, you can add a
+"<<PARTIAL>>": true key to the expected object, and any other keys in the
+actual object will be ignored:
+
+	ja.Assertf(`{"id": 1, "name": "Jayne"}`, `{"<<PARTIAL>>": true, "name": "Jayne"}`)
+
+Use the WithPartialObjects Option to apply this behavior to all objects.
+
+Any string that would otherwise be interpreted as a directive may be escaped
+by wrapping it in "<<LITERAL:...>>", e.g. "<<LITERAL:<<PRESENCE>>>>", or with
+Asserter.Escape when given as a format argument.
 */
 package jsonassert
 
@@ -55,6 +137,25 @@ type Printer interface {
 // See Asserter.Assertf for the main use of this package.
 type Asserter struct {
 	tt
+	partialObjects bool
+	tolerance      Tolerance
+	pathTolerances []pathTolerance
+	preciseNumbers bool
+	strictNumbers  bool
+	captures       *captureStore
+	matchers       map[string]Matcher
+
+	directivePrefix, directiveSuffix string
+	// dryRun is set when checking whether values are equal without
+	// reporting any differences, in which case captured values are only
+	// pending until the caller decides to keep them.
+	dryRun          bool
+	pendingCaptures map[string]interface{}
+
+	// root is the entire actual JSON of the current assertion, and
+	// afterwards holds checks to run once the rest of the assertion is done.
+	root       string
+	afterwards *[]func()
 }
 
 /*
@@ -64,8 +165,13 @@ you only need one *jsonassert.Asseter per (sub)test.
 In most cases, this will look something like
 
 	ja := jsonassert.New(t)
+
+The behavior of the Asserter can be tweaked by passing in one or more
+Options, e.g.
+
+	ja := jsonassert.New(t, jsonassert.WithPartialObjects())
 */
-func New(p Printer) *Asserter {
+func New(p Printer, opts ...Option) *Asserter {
 	// Initially this package was written without the assumption that the
 	// provided Printer will implement testing.tt, which includes the Helper()
 	// function to get better stacktraces in your testing utility functions.
@@ -76,10 +182,22 @@ func New(p Printer) *Asserter {
 	// printers that do not implement Helper(). This is done by wrapping the
 	// provided Printer into another struct that implements a NOOP Helper
 	// method.
+	a := &Asserter{
+		tt:        &noopHelperTT{Printer: p},
+		tolerance: defaultTolerance(),
+		captures:  &captureStore{values: map[string]interface{}{}},
+		matchers:  map[string]Matcher{},
+
+		directivePrefix: defaultDirectivePrefix,
+		directiveSuffix: defaultDirectiveSuffix,
+	}
 	if t, ok := p.(tt); ok {
-		return &Asserter{tt: t}
+		a.tt = t
 	}
-	return &Asserter{tt: &noopHelperTT{Printer: p}}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 /*
@@ -114,6 +232,11 @@ will verify that the UUID field is present, but does not check its actual value.
 You may use "<<PRESENCE>>" against any type of value. The only exception is null, which
 will result in an assertion failure.
 
+If you also care about the type of the value, you may use one of "<<STRING>>",
+"<<NUMBER>>", "<<BOOLEAN>>", "<<OBJECT>>", or "<<ARRAY>>" instead:
+
+	ja.Assertf(`{"id": 1234}`, `{"id":"<<NUMBER>>"}`)
+
 If you don't know / care about the order of the elements in an array in your
 payload, you can ignore the ordering:
 
@@ -125,12 +248,34 @@ the payload, but will ignore the order in which they appear.
 */
 func (a *Asserter) Assertf(actualJSON, expectedJSON string, fmtArgs ...interface{}) {
 	a.tt.Helper()
-	a.pathassertf("$", actualJSON, fmt.Sprintf(expectedJSON, fmtArgs...))
+	a.assert(actualJSON, fmt.Sprintf(expectedJSON, fmtArgs...))
 }
 
 // Assert works like Assertf, but does not accept fmt.Sprintf directives.
 // See Assert for details.
 func (a *Asserter) Assert(actualJSON, expectedJSON string) {
 	a.tt.Helper()
-	a.pathassertf("$", actualJSON, expectedJSON)
+	a.assert(actualJSON, expectedJSON)
+}
+
+func (a *Asserter) assert(actualJSON, expectedJSON string) {
+	a.tt.Helper()
+	call := *a
+	call.root, call.afterwards = actualJSON, &[]func(){}
+	call.recordCaptures(actualJSON, expectedJSON)
+	call.pathassertf("$", actualJSON, expectedJSON)
+	for _, check := range *call.afterwards {
+		check()
+	}
+}
+
+// after runs the check once the rest of the current assertion is done, or
+// immediately if there's no such assertion, e.g. when only checking whether
+// values are equal.
+func (a *Asserter) after(check func()) {
+	if a.afterwards == nil {
+		check()
+		return
+	}
+	*a.afterwards = append(*a.afterwards, check)
 }
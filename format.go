@@ -0,0 +1,81 @@
+package jsonassert
+
+import (
+	"encoding/base64"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// checkFormat verifies that act is a string of the given named format.
+// Returns false if the format is not known.
+func (a *Asserter) checkFormat(path, act, format string) bool {
+	a.tt.Helper()
+	actString, isString := extractString(act)
+	matches, known := matchesFormat(format, actString)
+	if !known {
+		return false
+	}
+	if !isString {
+		a.tt.Errorf("expected %s at '%s' but was %s", format, path, strings.TrimSpace(act))
+	} else if !matches {
+		a.tt.Errorf("expected %s at '%s' but was '%s'", format, path, actString)
+	}
+	return true
+}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// formats holds a validator for each of the named string formats.
+//
+//nolint:gochecknoglobals // never modified, just like the regexp above
+var formats = map[string]func(s string) bool{
+	"UUID": uuidRegex.MatchString,
+	"RFC3339": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"DATE": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"EMAIL": func(s string) bool {
+		// ParseAddress also accepts e.g. "Jayne Cobb <jayne@serenity.com>",
+		// which is not an email address in its own right.
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"URI": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"IP": func(s string) bool {
+		_, err := netip.ParseAddr(s)
+		return err == nil
+	},
+	"IPV4": func(s string) bool {
+		addr, err := netip.ParseAddr(s)
+		return err == nil && addr.Is4()
+	},
+	"IPV6": func(s string) bool {
+		addr, err := netip.ParseAddr(s)
+		return err == nil && addr.Is6()
+	},
+	"BASE64": func(s string) bool {
+		_, err := base64.StdEncoding.DecodeString(s)
+		return err == nil
+	},
+}
+
+// matchesFormat reports whether s is a string of the given named format, e.g.
+// "UUID". The second return value is false if the format is not known.
+func matchesFormat(format, s string) (matches, known bool) {
+	valid, known := formats[format]
+	if !known {
+		return false, false
+	}
+	return valid(s), true
+}
@@ -1,5 +1,7 @@
 package jsonassert
 
+import "encoding/json"
+
 // noopHelperTT is used to wrap the Printer in the case that users pass in an
 // Printer which does not implement a Helper() method. *testing.T does
 // implement this method so it is believed that this utility will be largely
@@ -21,9 +23,87 @@ type deepEqualityPrinter struct{ count int }
 func (p *deepEqualityPrinter) Errorf(_ string, _ ...interface{}) { p.count++ }
 func (p *deepEqualityPrinter) Helper()                           { /* Intentional NOOP */ }
 
-func (a *Asserter) deepEqual(act, exp interface{}) bool {
+func (a *Asserter) deepEqual(path string, act, exp interface{}) bool {
+	equal, _ := a.match(path, act, exp)
+	return equal
+}
+
+// match reports whether act matches exp without reporting any differences.
+// It also returns the values that any "<<CAPTURE:name>>" directives in exp
+// would record, which the caller may keep with keepCaptures if it goes with
+// this match.
+func (a *Asserter) match(path string, act, exp interface{}) (bool, map[string]interface{}) {
+	differences, captures := a.trial(path, act, exp)
+	return differences == 0, captures
+}
+
+// countDifferences returns the number of assertion failures that comparing
+// act and exp would result in, without reporting them.
+func (a *Asserter) countDifferences(path string, act, exp interface{}) int {
+	differences, _ := a.trial(path, act, exp)
+	return differences
+}
+
+func (a *Asserter) trial(path string, act, exp interface{}) (int, map[string]interface{}) {
+	p := &deepEqualityPrinter{count: 0}
+	deepEqualityAsserter := *a
+	deepEqualityAsserter.tt = p
+	deepEqualityAsserter.dryRun = true
+	deepEqualityAsserter.pendingCaptures = map[string]interface{}{}
+	deepEqualityAsserter.afterwards = nil
+	deepEqualityAsserter.pathassertf(path, serialize(act), serialize(exp))
+	return p.count, deepEqualityAsserter.pendingCaptures
+}
+
+// sameValues reports whether two decoded values from the actual JSON are
+// equal. Unlike deepEqual, neither value is treated as a template, so strings
+// that look like directives are compared as they are. Numbers are compared
+// the same way as anywhere else, e.g. using the configured tolerance.
+func (a *Asserter) sameValues(path string, x, y interface{}) bool {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		y, ok := y.(map[string]interface{})
+		return ok && a.sameObjects(path, x, y)
+	case []interface{}:
+		y, ok := y.([]interface{})
+		return ok && a.sameArrays(path, x, y)
+	case json.Number:
+		y, ok := y.(json.Number)
+		return ok && a.sameNumbers(path, x, y)
+	default:
+		return x == y // Strings, booleans, and null.
+	}
+}
+
+func (a *Asserter) sameObjects(path string, x, y map[string]interface{}) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for key, xVal := range x {
+		yVal, found := y[key]
+		if !found || !a.sameValues(path+"."+key, xVal, yVal) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Asserter) sameArrays(path string, x, y []interface{}) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if !a.sameValues(elementPath(path, i), x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Asserter) sameNumbers(path string, x, y json.Number) bool {
 	p := &deepEqualityPrinter{count: 0}
-	deepEqualityAsserter := &Asserter{tt: p}
-	deepEqualityAsserter.pathassertf("", serialize(act), serialize(exp))
+	numberAsserter := *a
+	numberAsserter.tt = p
+	numberAsserter.checkNumber(path, x.String(), y.String())
 	return p.count == 0
 }
@@ -1,9 +1,12 @@
 package jsonassert_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kinbiko/jsonassert"
 )
@@ -38,14 +41,444 @@ func TestAssertf(t *testing.T) {
 				"types":                    {`"true"`, `true`, []string{`actual JSON (string) and expected JSON (boolean) were of different types at '$'`}},
 				"0 bytes v null":           {``, `null`, []string{`'actual' JSON is not valid JSON: unable to identify JSON type of ""`}},
 				"booleans":                 {`false`, `true`, []string{`expected boolean at '$' to be true but was false`}},
-				"floats":                   {`12.45`, `1.245`, []string{`expected number at '$' to be '1.2450000' but was '12.4500000'`}},
-				"ints":                     {`1245`, `-1245`, []string{`expected number at '$' to be '-1245.0000000' but was '1245.0000000'`}},
+				"floats":                   {`12.45`, `1.245`, []string{`expected number at '$' to be '1.2450000' but was '12.4500000' (absolute tolerance 1e-06)`}},
+				"ints":                     {`1245`, `-1245`, []string{`expected number at '$' to be '-1245.0000000' but was '1245.0000000' (absolute tolerance 1e-06)`}},
 				"strings":                  {`"hello"`, `"world"`, []string{`expected string at '$' to be 'world' but was 'hello'`}},
 				"empty v non-empty string": {`""`, `"world"`, []string{`expected string at '$' to be 'world' but was ''`}},
 			} {
 				t.Run(name, func(t *testing.T) { tc.check(t) })
 			}
 		})
+
+		t.Run("with REGEX directive", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"matching string": {
+					`{"id": "94ae1a31-63b2-4a55-a478-47764b60c56b"}`,
+					`{"id": "<<REGEX:^[a-f0-9-]{36}$>>"}`,
+					nil,
+				},
+				"pattern containing colons": {`"12:34"`, `"<<REGEX:^\\d{2}:\\d{2}$>>"`, nil},
+				"non-matching string": {
+					`{"id": "hello"}`,
+					`{"id": "<<REGEX:^[0-9]+$>>"}`,
+					[]string{`expected string at '$.id' to match '^[0-9]+$' but was 'hello'`},
+				},
+				"non-matching long string": {
+					`"lorem ipsum dolor sit amet lorem ipsum dolor sit amet"`,
+					`"<<REGEX:^lorem ipsum$>>"`,
+					[]string{`expected string at '$' to match
+'^lorem ipsum$'
+but was
+'lorem ipsum dolor sit amet lorem ipsum dolor sit amet'`},
+				},
+				"non-string value": {
+					`{"id": 1234}`,
+					`{"id": "<<REGEX:^[0-9]+$>>"}`,
+					[]string{`actual JSON (number) and expected JSON (string) were of different types at '$.id'`},
+				},
+				"invalid pattern": {
+					`{"id": "hello"}`,
+					`{"id": "<<REGEX:(>>"}`,
+					[]string{"'expected' JSON contained an invalid directive '<<REGEX:(>>' at '$.id': error parsing regexp: missing closing ): `(`"},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with string matcher directives", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"matching strings": {
+					`{"url": "https://example.com/logo.png", "msg": "an error occurred", "greeting": "HELLO", "password": "hunter22"}`,
+					`{"url": "<<PREFIX:https://>>", "msg": "<<CONTAINS:error>>", "greeting": "<<IEQUALS:Hello>>", "password": "<<STRLEN:8..64>>"}`,
+					nil,
+				},
+				"suffix":                      {`"logo.png"`, `"<<SUFFIX:.png>>"`, nil},
+				"arguments containing colons": {`"https://example.com"`, `"<<PREFIX:https:>>"`, nil},
+				"non-matching prefix": {
+					`{"url": "http://example.com"}`,
+					`{"url": "<<PREFIX:https://>>"}`,
+					[]string{`expected string at '$.url' to start with 'https://' but was 'http://example.com'`},
+				},
+				"non-matching suffix": {
+					`{"img": "logo.jpg"}`,
+					`{"img": "<<SUFFIX:.png>>"}`,
+					[]string{`expected string at '$.img' to end with '.png' but was 'logo.jpg'`},
+				},
+				"non-matching contains": {
+					`{"msg": "all good"}`,
+					`{"msg": "<<CONTAINS:error>>"}`,
+					[]string{`expected string at '$.msg' to contain 'error' but was 'all good'`},
+				},
+				"non-matching case-insensitive equality": {
+					`{"greeting": "Goodbye"}`,
+					`{"greeting": "<<IEQUALS:Hello>>"}`,
+					[]string{`expected string at '$.greeting' to case-insensitively be 'Hello' but was 'Goodbye'`},
+				},
+				"non-matching long string": {
+					`"lorem ipsum dolor sit amet lorem ipsum dolor sit amet"`,
+					`"<<CONTAINS:consectetur>>"`,
+					[]string{`expected string at '$' to contain
+'consectetur'
+but was
+'lorem ipsum dolor sit amet lorem ipsum dolor sit amet'`},
+				},
+				"string length counts characters": {`"héllo"`, `"<<STRLEN:5>>"`, nil},
+				"string too short": {
+					`{"password": "abc"}`,
+					`{"password": "<<STRLEN:8..64>>"}`,
+					[]string{`length of string at '$.password' was out of bounds. Expected string to be of length 8..64, but contained 3 character(s)`},
+				},
+				"invalid string length": {
+					`{"password": "abc"}`,
+					`{"password": "<<STRLEN:lots>>"}`,
+					[]string{`'expected' JSON contained an invalid directive '<<STRLEN:lots>>' at '$.password': could not parse 'lots' as a number`},
+				},
+				"missing arguments": {
+					`{"a": "x", "b": "x", "c": "x", "d": "x", "e": "x", "f": "x"}`,
+					`{"a": "<<PREFIX:>>", "b": "<<SUFFIX>>", "c": "<<CONTAINS>>", "d": "<<IEQUALS:>>", "e": "<<REGEX:>>", "f": "<<STRLEN>>"}`,
+					[]string{
+						`'expected' JSON contained an invalid directive '<<PREFIX:>>' at '$.a': an argument is required`,
+						`'expected' JSON contained an invalid directive '<<SUFFIX>>' at '$.b': an argument is required`,
+						`'expected' JSON contained an invalid directive '<<CONTAINS>>' at '$.c': an argument is required`,
+						`'expected' JSON contained an invalid directive '<<IEQUALS:>>' at '$.d': an argument is required`,
+						`'expected' JSON contained an invalid directive '<<REGEX:>>' at '$.e': an argument is required`,
+						`'expected' JSON contained an invalid directive '<<STRLEN>>' at '$.f': an argument is required`,
+					},
+				},
+				"contains directive without an argument as a string value": {
+					`{"tags": "anything"}`,
+					`{"tags": "<<CONTAINS>>"}`,
+					[]string{`'expected' JSON contained an invalid directive '<<CONTAINS>>' at '$.tags': an argument is required`},
+				},
+				"non-string value": {
+					`{"url": 1234}`,
+					`{"url": "<<PREFIX:https://>>"}`,
+					[]string{`actual JSON (number) and expected JSON (string) were of different types at '$.url'`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with numeric directives", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"satisfied directives": {
+					`{"count": 3, "score": 99.5, "id": 1234, "pi": 3.1416, "neg": -1, "big": 1e3}`,
+					`{"count": "<<NUMBER:>0>>", "score": "<<RANGE:0..100>>", "id": "<<INT>>", "pi": "<<APPROX:3.14±0.01>>", "neg": "<<NUMBER:<0>>", "big": "<<INT>>"}`,
+					nil,
+				},
+				"ascii approximation": {`2.5`, `"<<APPROX:2+/-0.5>>"`, nil},
+				"unsatisfied directives": {
+					`{"count": 0, "score": 100.5, "id": 12.34, "pi": 3.2}`,
+					`{"count": "<<NUMBER:>0>>", "score": "<<RANGE:0..100>>", "id": "<<INT>>", "pi": "<<APPROX:3.14±0.01>>"}`,
+					[]string{
+						`expected number at '$.count' to satisfy '>0' but was '0'`,
+						`expected number at '$.score' to satisfy '0..100' but was '100.5'`,
+						`expected number at '$.id' to be an integer but was '12.34'`,
+						`expected number at '$.pi' to be '3.14±0.01' but was '3.2'`,
+					},
+				},
+				"non-number values": {
+					`["1", null]`,
+					`["<<RANGE:0..100>>", "<<INT>>"]`,
+					[]string{
+						`actual JSON (string) and expected JSON (number) were of different types at '$[0]'`,
+						`actual JSON (null) and expected JSON (number) were of different types at '$[1]'`,
+					},
+				},
+				"invalid directives": {
+					`[1, 2, 3]`,
+					`["<<RANGE:a..b>>", "<<APPROX:3.14>>", "<<APPROX:1±-1>>"]`,
+					[]string{
+						`'expected' JSON contained an invalid directive '<<RANGE:a..b>>' at '$[0]': could not parse 'a' as a number`,
+						`'expected' JSON contained an invalid directive '<<APPROX:3.14>>' at '$[1]': expected a number and tolerance such as '3.14±0.01' but was '3.14'`,
+						`'expected' JSON contained an invalid directive '<<APPROX:1±-1>>' at '$[2]': tolerance '-1' must not be negative`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with number tolerances", func(t *testing.T) {
+			t.Parallel()
+			for name, tt := range map[string]struct {
+				tc   *testCase
+				opts []jsonassert.Option
+			}{
+				"default tolerance": {
+					&testCase{`[1.0000001, 1.00001]`, `[1, 1]`, []string{
+						`expected number at '$[1]' to be '1.0000000' but was '1.0000100' (absolute tolerance 1e-06)`,
+					}},
+					nil,
+				},
+				"explicit default tolerance": {
+					&testCase{`[1.0000001, 1.00001]`, `[1, 1]`, []string{
+						`expected number at '$[1]' to be '1.0000000' but was '1.0000100' (absolute tolerance 1e-06)`,
+					}},
+					[]jsonassert.Option{jsonassert.WithNumberTolerance(jsonassert.AbsoluteTolerance(0.000001))},
+				},
+				"exact": {
+					&testCase{`[1, 1.0000001]`, `[1.0, 1]`, []string{
+						`expected number at '$[1]' to be '1' but was '1.0000001' (exact comparison)`,
+					}},
+					[]jsonassert.Option{jsonassert.WithNumberTolerance(jsonassert.ExactTolerance())},
+				},
+				"absolute": {
+					&testCase{`[1.05, 1.2]`, `[1, 1]`, []string{
+						`expected number at '$[1]' to be '1' but was '1.2' (absolute tolerance 0.1)`,
+					}},
+					[]jsonassert.Option{jsonassert.WithNumberTolerance(jsonassert.AbsoluteTolerance(0.1))},
+				},
+				"relative": {
+					&testCase{`[1000000001, 1000000002, 1e-9]`, `[1000000000, 1000000000, 2e-9]`, []string{
+						`expected number at '$[1]' to be '1000000000' but was '1000000002' (relative tolerance 1e-09)`,
+						`expected number at '$[2]' to be '2e-9' but was '1e-9' (relative tolerance 1e-09)`,
+					}},
+					[]jsonassert.Option{jsonassert.WithNumberTolerance(jsonassert.RelativeTolerance(1e-9))},
+				},
+				"ULP": {
+					&testCase{`[0.30000000000000004, -0.30000000000000004, 0.3000000000000001]`, `[0.3, -0.3, 0.3]`, []string{
+						`expected number at '$[2]' to be '0.3' but was '0.3000000000000001' (tolerance 1 ULP(s))`,
+					}},
+					[]jsonassert.Option{jsonassert.WithNumberTolerance(jsonassert.ULPTolerance(1))},
+				},
+				"path specific": {
+					&testCase{`{"price": 10.05, "items": [{"price": 1.2}, {"price": 2.05}], "qty": 1.05}`, `{"price": 10, "items": [{"price": 1}, {"price": 2}], "qty": 1}`, []string{
+						`expected number at '$.items[0].price' to be '1' but was '1.2' (absolute tolerance 0.1)`,
+						`expected number at '$.qty' to be '1' but was '1.05' (exact comparison)`,
+					}},
+					[]jsonassert.Option{
+						jsonassert.WithNumberTolerance(jsonassert.ExactTolerance()),
+						jsonassert.WithPathNumberTolerance("$.price", jsonassert.AbsoluteTolerance(0.1)),
+						jsonassert.WithPathNumberTolerance("$.items[*].price", jsonassert.AbsoluteTolerance(0.1)),
+					},
+				},
+				"path specific in unordered arrays": {
+					&testCase{`{"items": [2.05, 1.05]}`, `{"items": ["<<UNORDERED>>", 1, 2]}`, nil},
+					[]jsonassert.Option{
+						jsonassert.WithNumberTolerance(jsonassert.ExactTolerance()),
+						jsonassert.WithPathNumberTolerance("$.items[*]", jsonassert.AbsoluteTolerance(0.1)),
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tt.tc.check(t, tt.opts...) })
+			}
+		})
+
+		t.Run("with WithPreciseNumbers option", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"equal numbers in different notation": {`[1, 1.50, 100]`, `[1.0, 1.5, 1e2]`, nil},
+				"large integers": {
+					`{"id": 9007199254740993, "ids": [9007199254740993]}`,
+					`{"id": 9007199254740992, "ids": [9007199254740992]}`,
+					[]string{
+						`expected number at '$.id' to be '9007199254740992' but was '9007199254740993'`,
+						`expected number at '$.ids[0]' to be '9007199254740992' but was '9007199254740993'`,
+					},
+				},
+				"high precision decimals": {
+					`{"amount": 0.10000000000000000001}`,
+					`{"amount": 0.1}`,
+					[]string{`expected number at '$.amount' to be '0.1' but was '0.10000000000000000001'`},
+				},
+				"numbers beyond the range of float64": {
+					`{"big": 2e400, "small": -1e-400, "other": 3e400}`,
+					`{"big": 20e399, "small": -1e-400, "other": 2e400}`,
+					[]string{`expected number at '$.other' to be '2e400' but was '3e400'`},
+				},
+				"invalid numbers": {`{"a": 01}`, `{"a": 1}`, []string{`'actual' JSON is not valid JSON: unable to identify JSON type of "{"a": 01}"`}},
+				"large integers in unordered arrays": {
+					`[9007199254740993, 9007199254740992]`,
+					`["<<UNORDERED>>", 9007199254740992, 9007199254740993]`,
+					nil,
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t, jsonassert.WithPreciseNumbers()) })
+			}
+		})
+
+		t.Run("with WithStrictNumberRepresentation option", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"identical representations": {`{"a": 1, "b": [1.0, -2.5e3]}`, `{"a": 1, "b": [1.0, -2.5e3]}`, nil},
+				"different representations": {
+					`{"int": 1.0, "float": 1, "exp": 1e0, "top": [1.50]}`,
+					`{"int": 1, "float": 1.0, "exp": 1, "top": [1.5]}`,
+					[]string{
+						`expected number at '$.int' to be written as '1' but was '1.0'`,
+						`expected number at '$.float' to be written as '1.0' but was '1'`,
+						`expected number at '$.exp' to be written as '1' but was '1e0'`,
+						`expected number at '$.top[0]' to be written as '1.5' but was '1.50'`,
+					},
+				},
+				"different values":       {`2`, `1`, []string{`expected number at '$' to be written as '1' but was '2'`}},
+				"directives still apply": {`[1.0, 2]`, `["<<NUMBER>>", "<<INT>>"]`, nil},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t, jsonassert.WithStrictNumberRepresentation()) })
+			}
+		})
+
+		t.Run("with format directives", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"valid formats": {
+					`{
+						"uuid": "94ae1a31-63b2-4a55-a478-47764b60c56b",
+						"rfc3339": "2019-01-28T21:19:42.123+09:00",
+						"date": "2019-01-28",
+						"email": "jayne@serenity.com",
+						"uri": "https://example.com/path?q=1",
+						"ipv4": "127.0.0.1",
+						"ipv6": "::1",
+						"base64": "aGVsbG8gd29ybGQ="
+					}`,
+					`{
+						"uuid": "<<UUID>>",
+						"rfc3339": "<<RFC3339>>",
+						"date": "<<DATE>>",
+						"email": "<<EMAIL>>",
+						"uri": "<<URI>>",
+						"ipv4": "<<IP>>",
+						"ipv6": "<<IP>>",
+						"base64": "<<BASE64>>"
+					}`,
+					nil,
+				},
+				"invalid formats": {
+					`{
+						"uuid": "abc",
+						"rfc3339": "2019-01-28 21:19:42",
+						"date": "2019-13-28",
+						"email": "Jayne Cobb <jayne@serenity.com>",
+						"uri": "/relative/path",
+						"ip": "256.0.0.1",
+						"base64": "not base64!"
+					}`,
+					`{
+						"uuid": "<<UUID>>",
+						"rfc3339": "<<RFC3339>>",
+						"date": "<<DATE>>",
+						"email": "<<EMAIL>>",
+						"uri": "<<URI>>",
+						"ip": "<<IP>>",
+						"base64": "<<BASE64>>"
+					}`,
+					[]string{
+						`expected UUID at '$.uuid' but was 'abc'`,
+						`expected RFC3339 at '$.rfc3339' but was '2019-01-28 21:19:42'`,
+						`expected DATE at '$.date' but was '2019-13-28'`,
+						`expected EMAIL at '$.email' but was 'Jayne Cobb <jayne@serenity.com>'`,
+						`expected URI at '$.uri' but was '/relative/path'`,
+						`expected IP at '$.ip' but was '256.0.0.1'`,
+						`expected BASE64 at '$.base64' but was 'not base64!'`,
+					},
+				},
+				"IP versions": {
+					`["127.0.0.1", "::1", "::1", "127.0.0.1"]`,
+					`["<<IPV4>>", "<<IPV6>>", "<<IPV4>>", "<<IPV6>>"]`,
+					[]string{
+						`expected IPV4 at '$[2]' but was '::1'`,
+						`expected IPV6 at '$[3]' but was '127.0.0.1'`,
+					},
+				},
+				"non-string values": {
+					`{"id": 1234, "time": null}`,
+					`{"id": "<<UUID>>", "time": "<<RFC3339>>"}`,
+					[]string{
+						`expected UUID at '$.id' but was 1234`,
+						`expected RFC3339 at '$.time' but was null`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with TIME directive", func(t *testing.T) {
+			t.Parallel()
+			now := time.Now()
+			for name, tc := range map[string]*testCase{
+				"valid times": {
+					`{"rfc3339": "2024-01-01T12:00:00.5+01:00", "unix": 1704110400, "unixms": 1704110400500}`,
+					`{"rfc3339": "<<TIME>>", "unix": "<<TIME>>", "unixms": "<<TIME:UNIX_MS>>"}`,
+					nil,
+				},
+				"within window of now": {
+					fmt.Sprintf(`{"createdAt": "%s", "unix": %d}`, now.Format(time.RFC3339), now.Unix()),
+					`{"createdAt": "<<TIME:within=1m>>", "unix": "<<TIME:UNIX,within=1m,at=now>>"}`,
+					nil,
+				},
+				"within window of given time": {
+					`{"createdAt": "2024-01-01T12:00:04Z", "unixms": 1704110404000}`,
+					`{"createdAt": "<<TIME:within=5s,at=2024-01-01T12:00:00Z>>", "unixms": "<<TIME:UNIX_MS,within=5s,at=2024-01-01T12:00:00Z>>"}`,
+					nil,
+				},
+				"outside window of given time": {
+					`{"createdAt": "2024-01-01T12:00:10Z", "updatedAt": 1704110390}`,
+					`{"createdAt": "<<TIME:within=5s,at=2024-01-01T12:00:00Z>>", "updatedAt": "<<TIME:within=5s,at=2024-01-01T12:00:00Z>>"}`,
+					[]string{
+						`expected time at '$.createdAt' to be within 5s of 2024-01-01T12:00:00Z but was "2024-01-01T12:00:10Z", 10s later`,
+						`expected time at '$.updatedAt' to be within 5s of 2024-01-01T12:00:00Z but was 1704110390, 10s earlier`,
+					},
+				},
+				"epoch millis with the default layout": {
+					fmt.Sprintf(`{"now": %d, "given": 1704110404000}`, now.UnixMilli()),
+					`{"now": "<<TIME:within=1m>>", "given": "<<TIME:within=5s,at=2024-01-01T12:00:00Z>>"}`,
+					nil,
+				},
+				"epoch millis with the default layout outside the window": {
+					`{"given": 1704110410000}`,
+					`{"given": "<<TIME:within=5s,at=2024-01-01T12:00:00Z>>"}`,
+					[]string{`expected time at '$.given' to be within 5s of 2024-01-01T12:00:00Z but was 1704110410000, 10s later`},
+				},
+				"out of range epochs": {
+					`{"a": 99999999999999999999, "b": -1e30, "c": 1e300}`,
+					`{"a": "<<TIME:UNIX,within=5s>>", "b": "<<TIME:within=5s>>", "c": "<<TIME:UNIX_MS>>"}`,
+					[]string{
+						`expected UNIX time at '$.a' but was 99999999999999999999`,
+						`expected UNIX_MS time at '$.b' but was -1e30`,
+						`expected UNIX_MS time at '$.c' but was 1e300`,
+					},
+				},
+				"invalid times": {
+					`{"rfc3339": "yesterday", "unix": "1704110400", "other": true}`,
+					`{"rfc3339": "<<TIME>>", "unix": "<<TIME:UNIX>>", "other": "<<TIME>>"}`,
+					[]string{
+						`expected RFC3339 time at '$.rfc3339' but was "yesterday"`,
+						`expected UNIX time at '$.unix' but was "1704110400"`,
+						`expected RFC3339 time at '$.other' but was true`,
+					},
+				},
+				"invalid directives": {
+					`{"a": "2024-01-01T12:00:00Z", "b": "2024-01-01T12:00:00Z", "c": "2024-01-01T12:00:00Z", "d": "2024-01-01T12:00:00Z"}`,
+					`{"a": "<<TIME:within=soon>>", "b": "<<TIME:at=noon>>", "c": "<<TIME:ISO8601>>", "d": "<<TIME:around=now>>"}`,
+					[]string{
+						`'expected' JSON contained an invalid directive '<<TIME:within=soon>>' at '$.a': could not parse 'soon' as a non-negative duration`,
+						`'expected' JSON contained an invalid directive '<<TIME:at=noon>>' at '$.b': could not parse 'noon' as an RFC3339 time`,
+						`'expected' JSON contained an invalid directive '<<TIME:ISO8601>>' at '$.c': unknown time layout 'ISO8601'`,
+						`'expected' JSON contained an invalid directive '<<TIME:around=now>>' at '$.d': unknown option 'around'`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+
+			t.Run("outside window of now", func(t *testing.T) {
+				// The exact difference depends on the current time.
+				tp := &testPrinter{messages: nil}
+				jsonassert.New(tp).Assert(`{"createdAt": "2001-01-01T00:00:00Z"}`, `{"createdAt": "<<TIME:within=1h>>"}`)
+				prefix := `expected time at '$.createdAt' to be within 1h0m0s of now but was "2001-01-01T00:00:00Z", `
+				if len(tp.messages) != 1 || !strings.HasPrefix(tp.messages[0], prefix) || !strings.HasSuffix(tp.messages[0], " earlier") {
+					t.Errorf("unexpected assertion messages: %q", tp.messages)
+				}
+			})
+		})
 	})
 
 	t.Run("objects", func(t *testing.T) {
@@ -150,6 +583,156 @@ func TestAssertf(t *testing.T) {
 				t.Run(name, func(t *testing.T) { tc.check(t) })
 			}
 		})
+
+		t.Run("with PARTIAL directive", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"extra keys are ignored": {
+					`{"id": 1, "name": "Jayne", "age": 36}`,
+					`{"<<PARTIAL>>": true, "name": "Jayne"}`,
+					nil,
+				},
+				"mismatches are still reported": {
+					`{"id": 1, "name": "Jayne"}`,
+					`{"<<PARTIAL>>": true, "name": "Mal", "age": 36}`,
+					[]string{
+						`expected string at '$.name' to be 'Mal' but was 'Jayne'`,
+						`expected object key(s) ["age"] missing at '$'`,
+					},
+				},
+				"only applies to a single level": {
+					`{"id": 1, "crew": {"name": "Jayne", "age": 36}}`,
+					`{"<<PARTIAL>>": true, "crew": {"name": "Jayne"}}`,
+					[]string{
+						`expected 1 keys at '$.crew' but got 2 keys`,
+						`unexpected object key(s) ["age"] found at '$.crew'`,
+					},
+				},
+				"false is a regular comparison": {
+					`{"id": 1}`,
+					`{"<<PARTIAL>>": false}`,
+					[]string{
+						`expected 0 keys at '$' but got 1 keys`,
+						`unexpected object key(s) ["id"] found at '$'`,
+					},
+				},
+				"non-boolean value": {
+					`{"id": 1}`,
+					`{"<<PARTIAL>>": "yes", "id": 1}`,
+					[]string{`'expected' JSON contained an invalid directive '<<PARTIAL>>' at '$': expected a boolean value but was "yes"`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with WithPartialObjects option", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"extra keys are ignored at all levels": {
+					`{"id": 1, "crew": [{"name": "Jayne", "age": 36}]}`,
+					`{"crew": [{"name": "Jayne"}]}`,
+					nil,
+				},
+				"missing keys are still reported": {
+					`{"id": 1}`,
+					`{"name": "Jayne"}`,
+					[]string{`expected object key(s) ["name"] missing at '$'`},
+				},
+				"unordered arrays use partial objects": {
+					`[{"id": 1, "name": "Jayne"}, {"id": 2, "name": "Mal"}]`,
+					`["<<UNORDERED>>", {"name": "Mal"}, {"name": "Jayne"}]`,
+					nil,
+				},
+				"individual objects may opt out": {
+					`{"id": 1, "crew": {"name": "Jayne", "age": 36}}`,
+					`{"crew": {"<<PARTIAL>>": false, "name": "Jayne"}}`,
+					[]string{
+						`expected 1 keys at '$.crew' but got 2 keys`,
+						`unexpected object key(s) ["age"] found at '$.crew'`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t, jsonassert.WithPartialObjects()) })
+			}
+		})
+
+		t.Run("with typed presence directives", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"all types match": {
+					`{"s": "hello", "n": 12.3, "b": false, "o": {"a": 1}, "a": [1, "2"]}`,
+					`{"s": "<<STRING>>", "n": "<<NUMBER>>", "b": "<<BOOLEAN>>", "o": "<<OBJECT>>", "a": "<<ARRAY>>"}`,
+					nil,
+				},
+				"empty values match": {
+					`{"s": "", "n": 0, "o": {}, "a": []}`,
+					`{"s": "<<STRING>>", "n": "<<NUMBER>>", "o": "<<OBJECT>>", "a": "<<ARRAY>>"}`,
+					nil,
+				},
+				"number changed to string": {
+					`{"foo": "1234"}`,
+					`{"foo": "<<NUMBER>>"}`,
+					[]string{`actual JSON (string) and expected JSON (number) were of different types at '$.foo'`},
+				},
+				"null is not a string": {
+					`{"foo": null}`,
+					`{"foo": "<<STRING>>"}`,
+					[]string{`actual JSON (null) and expected JSON (string) were of different types at '$.foo'`},
+				},
+				"mismatches in nested structures": {
+					`{"foo": [true, {"bar": []}]}`,
+					`{"foo": ["<<OBJECT>>", {"bar": "<<BOOLEAN>>"}]}`,
+					[]string{
+						`actual JSON (boolean) and expected JSON (object) were of different types at '$.foo[0]'`,
+						`actual JSON (array) and expected JSON (boolean) were of different types at '$.foo[1].bar'`,
+					},
+				},
+				"any against null": {
+					`{"foo": null}`,
+					`{"foo": "<<ANY>>"}`,
+					nil,
+				},
+				"any against a value": {
+					`{"foo": [1, {"bar": "baz"}]}`,
+					`{"foo": "<<ANY>>"}`,
+					nil,
+				},
+				"any against a missing key": {
+					`{}`,
+					`{"foo": "<<ANY>>"}`,
+					[]string{
+						`expected 1 keys at '$' but got 0 keys`,
+						`expected object key(s) ["foo"] missing at '$'`,
+					},
+				},
+				"optional keys present or missing": {
+					`{"foo": null, "bar": 1}`,
+					`{"foo": "<<OPTIONAL>>", "bar": 1, "baz": "<<OPTIONAL>>"}`,
+					nil,
+				},
+				"absent keys missing": {
+					`{"foo": 1}`,
+					`{"foo": 1, "password": "<<ABSENT>>"}`,
+					nil,
+				},
+				"absent keys present": {
+					`{"foo": 1, "password": "hunter2", "token": null}`,
+					`{"foo": 1, "password": "<<ABSENT>>", "token": "<<ABSENT>>"}`,
+					[]string{
+						`expected the absence of any value at '$.password', but was "hunter2"`,
+						`expected the absence of any value at '$.token', but was null`,
+					},
+				},
+				"lowercase directives are regular strings": {
+					`{"foo": "bar"}`,
+					`{"foo": "<<string>>"}`,
+					[]string{`expected string at '$.foo' to be '<<string>>' but was 'bar'`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
 	})
 
 	t.Run("arrays", func(t *testing.T) {
@@ -291,6 +874,46 @@ but expected JSON was:
 						`actual JSON at '$' was: ["foo","boo","foo"], but expected JSON was: ["foo","boo"], potentially in a different order`,
 					},
 				},
+				"duplicates are matched one-to-one": {
+					`["a", "a", "b"]`,
+					`["<<UNORDERED>>", "a", "b", "b"]`,
+					[]string{
+						`actual JSON at '$[1]' contained an unexpected element: "a"`,
+						`expected JSON at '$[2]': "b" was missing from actual payload`,
+					},
+				},
+				"overlapping templates are matched one-to-one": {
+					`[{"id": 1, "name": "Jayne"}, {"id": 2, "name": "Mal"}]`,
+					`["<<UNORDERED>>", {"id": "<<NUMBER>>", "name": "<<STRING>>"}, {"id": 1, "name": "Jayne"}]`,
+					nil,
+				},
+				"unmatched objects are compared against the closest candidate": {
+					`[
+						{"id": 1, "name": "Jayne", "role": "mercenary", "ship": "Serenity"},
+						{"id": 2, "name": "Mal", "role": "captain", "ship": "Serenity"},
+						{"id": 3, "name": "Wash", "role": "pilot", "ship": "Serenity"}
+					]`,
+					`["<<UNORDERED>>",
+						{"id": 2, "name": "Mal", "role": "captain", "ship": "Serenity"},
+						{"id": 3, "name": "Zoe", "role": "first mate", "ship": "Serenity"},
+						{"id": 1, "name": "Jane", "role": "mercenary", "ship": "Serenity"}
+					]`,
+					[]string{
+						`actual JSON at '$[0]' did not match any expected element, closest match was expected JSON at '$[2]' with differences:`,
+						`expected string at '$[0].name' to be 'Jane' but was 'Jayne'`,
+						`actual JSON at '$[2]' did not match any expected element, closest match was expected JSON at '$[1]' with differences:`,
+						`expected string at '$[2].name' to be 'Zoe' but was 'Wash'`,
+						`expected string at '$[2].role' to be 'first mate' but was 'pilot'`,
+					},
+				},
+				"unmatched objects without candidates": {
+					`[{"id": 1}, "foo"]`,
+					`["<<UNORDERED>>", "bar", "foo"]`,
+					[]string{
+						`actual JSON at '$[0]' contained an unexpected element: {"id":1}`,
+						`expected JSON at '$[0]': "bar" was missing from actual payload`,
+					},
+				},
 				"nested unordered arrays": {
 					// really long object means that serializing it the same is
 					// highly unlikely should the determinism of JSON
@@ -309,6 +932,735 @@ but expected JSON was:
 				t.Run(name, func(t *testing.T) { tc.check(t) })
 			}
 		})
+
+		t.Run("with CONTAINS directive", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"no expected elements":      {`["foo"]`, `["<<CONTAINS>>"]`, nil},
+				"subset in different order": {`["foo", "bar", "baz"]`, `["<<CONTAINS>>", "baz", "foo"]`, nil},
+				"nested templates": {
+					`[{"id": 1, "type": "created"}, {"id": 2, "type": "updated"}]`,
+					`["<<CONTAINS>>", {"id": "<<NUMBER>>", "type": "updated"}]`,
+					nil,
+				},
+				"duplicates are matched one-to-one": {
+					`["foo", "bar"]`,
+					`["<<CONTAINS>>", "foo", "foo"]`,
+					[]string{`expected JSON at '$[1]': "foo" was missing from actual payload`},
+				},
+				"missing elements": {
+					`["foo", "bar"]`,
+					`["<<CONTAINS>>", "bar", "baz", "qux"]`,
+					[]string{
+						`expected JSON at '$[1]': "baz" was missing from actual payload`,
+						`expected JSON at '$[2]': "qux" was missing from actual payload`,
+					},
+				},
+				"string directive as first element": {
+					`["foo bar", "x"]`,
+					`["<<CONTAINS:zzz>>", "y"]`,
+					[]string{
+						`expected string at '$[0]' to contain 'zzz' but was 'foo bar'`,
+						`expected string at '$[1]' to be 'y' but was 'x'`,
+					},
+				},
+				"array directives with arguments": {
+					`{"a": [1], "b": [1], "c": [1]}`,
+					`{"a": ["<<UNORDERED:junk>>", 1], "b": ["<<ONLY:1>>", 1], "c": ["<<CONTAINS_ORDERED:x>>", 1]}`,
+					[]string{
+						`'expected' JSON contained an invalid directive '<<UNORDERED:junk>>' at '$.a': array directives other than EACH do not take an argument`,
+						`'expected' JSON contained an invalid directive '<<ONLY:1>>' at '$.b': array directives other than EACH do not take an argument`,
+						`'expected' JSON contained an invalid directive '<<CONTAINS_ORDERED:x>>' at '$.c': array directives other than EACH do not take an argument`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with CONTAINS_ORDERED directive", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"subsequence":         {`["a", "b", "c", "d"]`, `["<<CONTAINS_ORDERED>>", "b", "d"]`, nil},
+				"identical elements":  {`["a", "b"]`, `["<<CONTAINS_ORDERED>>", "a", "b"]`, nil},
+				"repeated candidates": {`["a", "b", "a"]`, `["<<CONTAINS_ORDERED>>", "a", "a"]`, nil},
+				"out of order": {
+					`["a", "b", "c"]`,
+					`["<<CONTAINS_ORDERED>>", "c", "a"]`,
+					[]string{`expected JSON at '$[1]': "a" was missing from actual payload, or was out of order`},
+				},
+				"missing element": {
+					`["a", "b", "c"]`,
+					`["<<CONTAINS_ORDERED>>", "a", "x", "c"]`,
+					[]string{`expected JSON at '$[1]': "x" was missing from actual payload, or was out of order`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with EACH directive", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"empty array": {`[]`, `["<<EACH>>", "<<STRING>>"]`, nil},
+				"all elements match": {
+					`{"items": [{"id": "94ae1a31-63b2-4a55-a478-47764b60c56b", "name": "foo"}, {"id": "9d4a0e5c-7f38-4b5a-9c61-2d3e4f5a6b7c", "name": "bar"}]}`,
+					`{"items": ["<<EACH>>", {"id": "<<UUID>>", "name": "<<STRING>>"}]}`,
+					nil,
+				},
+				"mismatching elements": {
+					`{"items": [{"id": 1, "name": "foo"}, {"id": 2, "name": null}, {"id": 3}]}`,
+					`{"items": ["<<EACH>>", {"id": "<<NUMBER>>", "name": "<<STRING>>"}]}`,
+					[]string{
+						`actual JSON (null) and expected JSON (string) were of different types at '$.items[1].name'`,
+						`expected 2 keys at '$.items[2]' but got 1 keys`,
+						`expected object key(s) ["name"] missing at '$.items[2]'`,
+					},
+				},
+				"length within bounds": {`[1, 2, 3]`, `["<<EACH:1..3>>", "<<NUMBER>>"]`, nil},
+				"open ended bounds":    {`[1, 2, 3]`, `["<<EACH:1..>>", "<<NUMBER>>"]`, nil},
+				"comparison bounds":    {`[1, 2, 3]`, `["<<EACH:>=1>>", "<<NUMBER>>"]`, nil},
+				"length out of bounds": {
+					`[1, 2, "3"]`,
+					`["<<EACH:..2>>", "<<NUMBER>>"]`,
+					[]string{
+						`length of array at '$' was out of bounds. Expected array to be of length ..2, but contained 3 element(s)`,
+						`actual JSON (string) and expected JSON (number) were of different types at '$[2]'`,
+					},
+				},
+				"missing template": {
+					`[1, 2]`,
+					`["<<EACH>>"]`,
+					[]string{`'expected' JSON contained an invalid directive '<<EACH>>' at '$': must be followed by exactly one element`},
+				},
+				"invalid bounds": {
+					`[1, 2]`,
+					`["<<EACH:3..1>>", 1]`,
+					[]string{`'expected' JSON contained an invalid directive '<<EACH:3..1>>' at '$': lower bound '3' is greater than upper bound '1'`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with length directives", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"exact lengths": {
+					`{"arr": [1, 2, 3], "str": "世界", "obj": {"a": 1}}`,
+					`{"arr": "<<LEN:3>>", "str": "<<LEN:2>>", "obj": "<<LEN:1>>"}`,
+					nil,
+				},
+				"comparisons": {
+					`[[1], [1, 2], [1, 2, 3], []]`,
+					`["<<LEN:>=1>>", "<<LEN:>1>>", "<<LEN:<=3>>", "<<LEN:<1>>"]`,
+					nil,
+				},
+				"ranges": {
+					`[[1], [1, 2], [1, 2, 3]]`,
+					`["<<LEN:1..3>>", "<<LEN:2..>>", "<<LEN:..3>>"]`,
+					nil,
+				},
+				"non-empty": {
+					`[[1], "a", {"a": 1}]`,
+					`["<<NONEMPTY>>", "<<NONEMPTY>>", "<<NONEMPTY>>"]`,
+					nil,
+				},
+				"out of bounds": {
+					`{"arr": [1, 2], "str": "", "obj": {}, "gt": [1]}`,
+					`{"arr": "<<LEN:3>>", "str": "<<NONEMPTY>>", "obj": "<<LEN:1..2>>", "gt": "<<LEN:>1>>"}`,
+					[]string{
+						`length of array at '$.arr' was out of bounds. Expected array to be of length 3, but contained 2 element(s)`,
+						`length of string at '$.str' was out of bounds. Expected string to be of length >=1, but contained 0 character(s)`,
+						`length of object at '$.obj' was out of bounds. Expected object to be of length 1..2, but contained 0 key(s)`,
+						`length of array at '$.gt' was out of bounds. Expected array to be of length >1, but contained 1 element(s)`,
+					},
+				},
+				"types without length": {
+					`[1, null]`,
+					`["<<LEN:1>>", "<<NONEMPTY>>"]`,
+					[]string{
+						`expected array, string, or object at '$[0]' but was number`,
+						`expected array, string, or object at '$[1]' but was null`,
+					},
+				},
+				"invalid constraint": {
+					`[1]`,
+					`"<<LEN:one>>"`,
+					[]string{`'expected' JSON contained an invalid directive '<<LEN:one>>' at '$': could not parse 'one' as a number`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with ONLY directive", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"empty array":        {`[]`, `["<<ONLY>>", "a", "b"]`, nil},
+				"subset of elements": {`["b", "b"]`, `["<<ONLY>>", "a", "b"]`, nil},
+				"unexpected elements": {
+					`["a", "c", "b", {"d": 1}]`,
+					`["<<ONLY>>", "a", "b"]`,
+					[]string{
+						`actual JSON at '$[1]' contained an unexpected element: "c"`,
+						`actual JSON at '$[3]' contained an unexpected element: {"d":1}`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with SORTED and UNIQUE directives", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"sorted arrays": {
+					`{"nums": [1, 2, 2, 10.5], "strs": ["c", "b", "a"], "empty": []}`,
+					`{"nums": ["<<SORTED>>"], "strs": ["<<SORTED:desc>>"], "empty": ["<<SORTED:asc>>"]}`,
+					nil,
+				},
+				"sorted by key": {
+					`[{"id": 3, "createdAt": "2024-01-03"}, {"id": 1, "createdAt": "2024-01-02"}]`,
+					`["<<SORTED:desc,by=$.createdAt>>"]`,
+					nil,
+				},
+				"combined with element checks": {
+					`[{"id": 1}, {"id": 2}]`,
+					`["<<SORTED:by=$.id>>", "<<UNIQUE:$.id>>", "<<EACH>>", {"id": "<<NUMBER>>"}]`,
+					nil,
+				},
+				"combined with ordered elements": {
+					`[1, 2, 3]`,
+					`["<<SORTED>>", 1, 2, 4]`,
+					[]string{`expected number at '$[2]' to be '4.0000000' but was '3.0000000' (absolute tolerance 1e-06)`},
+				},
+				"unsorted arrays": {
+					`{"nums": [1, 3, 2], "items": [{"createdAt": "2024-01-01"}, {"createdAt": "2024-01-02"}]}`,
+					`{"nums": ["<<SORTED:asc>>"], "items": ["<<SORTED:desc,by=$.createdAt>>"]}`,
+					[]string{
+						`expected array at '$.nums' to be sorted in ascending order, but '$.nums[1]' (3) came before '$.nums[2]' (2)`,
+						`expected array at '$.items' to be sorted in descending order, but '$.items[0].createdAt' ("2024-01-01") came before '$.items[1].createdAt' ("2024-01-02")`,
+					},
+				},
+				"unsortable values": {
+					`[1, "2"]`,
+					`["<<SORTED>>"]`,
+					[]string{`expected values at '$[0]' and '$[1]' to both be numbers or strings in order to be sorted, but were 1 and "2"`},
+				},
+				"unique arrays": {
+					`{"nums": [1, 2, 3], "items": [{"id": 1, "v": "a"}, {"id": 2, "v": "a"}]}`,
+					`{"nums": ["<<UNIQUE>>"], "items": ["<<UNIQUE:$.id>>"]}`,
+					nil,
+				},
+				"duplicate elements": {
+					`{"nums": [1, 2, 1, 1], "items": [{"id": 1}, {"id": 2}, {"id": 2}]}`,
+					`{"nums": ["<<UNIQUE>>"], "items": ["<<UNIQUE:$.id>>"]}`,
+					[]string{
+						`expected unique values in array at '$.nums', but '$.nums[2]' was the same as '$.nums[0]': 1`,
+						`expected unique values in array at '$.nums', but '$.nums[3]' was the same as '$.nums[0]': 1`,
+						`expected unique values in array at '$.items', but '$.items[2].id' was the same as '$.items[1].id': 2`,
+					},
+				},
+				"values that look like directives": {`["<<PRESENCE>>", "a", "<<ANY>>"]`, `["<<UNIQUE>>"]`, nil},
+				"missing keys": {
+					`[{"id": 1}, {"name": "x"}]`,
+					`["<<UNIQUE:$.id>>"]`,
+					[]string{`expected element at '$[1]' to have a value at '$.id', but there was no object key 'id' at '$'`},
+				},
+				"unique by key option": {
+					`[{"id": 1}, {"id": 2}, {"id": 1}]`,
+					`["<<UNIQUE:by=$.id>>"]`,
+					[]string{`expected unique values in array at '$', but '$[2].id' was the same as '$[0].id': 1`},
+				},
+				"invalid directives": {
+					`{"a": [1], "b": [1], "c": [1]}`,
+					`{"a": ["<<SORTED:up>>"], "b": ["<<UNIQUE:id>>"], "c": ["<<UNIQUE:by=$,desc>>"]}`,
+					[]string{
+						`'expected' JSON contained an invalid directive '<<SORTED:up>>' at '$.a': unknown option 'up'`,
+						`'expected' JSON contained an invalid directive '<<UNIQUE:id>>' at '$.b': path 'id' must start with '$'`,
+						`'expected' JSON contained an invalid directive '<<UNIQUE:by=$,desc>>' at '$.c': unknown option 'desc'`,
+					},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("with SORTED directive and precise numbers", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"sorted large integers": {`[9007199254740992, 9007199254740993]`, `["<<SORTED:asc>>"]`, nil},
+				"unsorted large integers": {
+					`[9007199254740993, 9007199254740992]`,
+					`["<<SORTED:asc>>"]`,
+					[]string{`expected array at '$' to be sorted in ascending order, but '$[0]' (9007199254740993) came before '$[1]' (9007199254740992)`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t, jsonassert.WithPreciseNumbers()) })
+			}
+		})
+	})
+
+	t.Run("with CAPTURE and REF directives", func(t *testing.T) {
+		t.Parallel()
+		t.Run("within the same payload", func(t *testing.T) {
+			t.Parallel()
+			for name, tc := range map[string]*testCase{
+				"equal references": {
+					`{"id": "abc", "links": {"self": {"id": "abc"}}, "owners": [{"user": 1}], "author": 1}`,
+					`{"id": "<<CAPTURE:id>>", "links": {"self": {"id": "<<REF:id>>"}}, "owners": [{"user": "<<CAPTURE:user>>"}], "author": "<<REF:user>>"}`,
+					nil,
+				},
+				"references before captures": {
+					`{"a": {"b": {"c": "xyz"}}, "z": "xyz"}`,
+					`{"a": {"b": {"c": "<<REF:id>>"}}, "z": "<<CAPTURE:id>>"}`,
+					nil,
+				},
+				"references in unordered arrays": {
+					`{"id": 2, "items": [{"owner": 1}, {"owner": 2}]}`,
+					`{"id": "<<CAPTURE:id>>", "items": ["<<UNORDERED>>", {"owner": "<<REF:id>>"}, {"owner": 1}]}`,
+					nil,
+				},
+				"different references": {
+					`{"id": "abc", "copy": "abd", "obj": {"a": [1]}, "objCopy": {"a": [2]}}`,
+					`{"id": "<<CAPTURE:id>>", "copy": "<<REF:id>>", "obj": "<<CAPTURE:obj>>", "objCopy": "<<REF:obj>>"}`,
+					[]string{
+						`expected value at '$.copy' to be "abc", as captured by 'id', but was "abd"`,
+						`expected value at '$.objCopy' to be {"a":[1]}, as captured by 'obj', but was {"a":[2]}`,
+					},
+				},
+				"captured values that look like directives": {
+					`{"a": "<<PRESENCE>>", "b": "zzz", "c": ["<<UNORDERED>>", 1, 2], "d": ["<<UNORDERED>>", 2, 1], "e": {"<<PARTIAL>>": true}, "f": {"<<PARTIAL>>": true, "x": 1}}`,
+					`{"a": "<<CAPTURE:a>>", "b": "<<REF:a>>", "c": "<<CAPTURE:c>>", "d": "<<REF:c>>", "e": "<<CAPTURE:e>>", "f": "<<REF:e>>"}`,
+					[]string{
+						`expected value at '$.b' to be "\u003c\u003cPRESENCE\u003e\u003e", as captured by 'a', but was "zzz"`,
+						`expected value at '$.d' to be ["\u003c\u003cUNORDERED\u003e\u003e",1,2], as captured by 'c', but was ["\u003c\u003cUNORDERED\u003e\u003e",2,1]`,
+						`expected value at '$.f' to be {"\u003c\u003cPARTIAL\u003e\u003e":true}, as captured by 'e', but was {"\u003c\u003cPARTIAL\u003e\u003e":true,"x":1}`,
+					},
+				},
+				"captured numbers": {
+					`{"a": 1, "b": 1.0, "c": 2}`,
+					`{"a": "<<CAPTURE:n>>", "b": "<<REF:n>>", "c": "<<REF:n>>"}`,
+					[]string{`expected value at '$.c' to be 1, as captured by 'n', but was 2`},
+				},
+				"unknown reference": {
+					`{"id": "abc"}`,
+					`{"id": "<<REF:id>>"}`,
+					[]string{`'expected' JSON contained an invalid directive '<<REF:id>>' at '$.id': no value has been captured as 'id'`},
+				},
+				"missing name": {
+					`{"id": "abc"}`,
+					`{"id": "<<CAPTURE>>"}`,
+					[]string{`'expected' JSON contained an invalid directive '<<CAPTURE>>' at '$.id': a name is required`},
+				},
+				"captures in unordered arrays": {
+					`{"a": [{"id": "x"}], "b": "x"}`,
+					`{"a": ["<<UNORDERED>>", {"id": "<<CAPTURE:id>>"}], "b": "<<REF:id>>"}`,
+					nil,
+				},
+				"captures of the matched unordered elements": {
+					`{"a": [{"k": 1, "id": "x"}, {"k": 2, "id": "y"}], "b": "y"}`,
+					`{"a": ["<<UNORDERED>>", {"k": 2, "id": "<<CAPTURE:id>>"}, {"k": 1, "id": "<<ANY>>"}], "b": "<<REF:id>>"}`,
+					nil,
+				},
+				"captures in contained elements": {
+					`{"a": [1, {"id": "x"}], "b": "y"}`,
+					`{"a": ["<<CONTAINS>>", {"id": "<<CAPTURE:id>>"}], "b": "<<REF:id>>"}`,
+					[]string{`expected value at '$.b' to be "x", as captured by 'id', but was "y"`},
+				},
+				"captures in elements contained in order": {
+					`{"a": [{"id": "x"}, 1], "b": "y"}`,
+					`{"a": ["<<CONTAINS_ORDERED>>", {"id": "<<CAPTURE:id>>"}, 1], "b": "<<REF:id>>"}`,
+					[]string{`expected value at '$.b' to be "x", as captured by 'id', but was "y"`},
+				},
+				"captures in arrays with only the given elements": {
+					`{"a": [{"id": "x"}], "b": "y"}`,
+					`{"a": ["<<ONLY>>", {"id": "<<CAPTURE:id>>"}, 1], "b": "<<REF:id>>"}`,
+					[]string{`expected value at '$.b' to be "x", as captured by 'id', but was "y"`},
+				},
+				"captures in the matching alternative": {
+					`{"a": {"type": "b", "id": "x"}, "b": "y"}`,
+					`{"a": {"<<ONEOF>>": [{"type": "a", "id": "<<CAPTURE:id>>"}, {"type": "b", "id": "<<CAPTURE:id>>"}]}, "b": "<<REF:id>>"}`,
+					[]string{`expected value at '$.b' to be "x", as captured by 'id', but was "y"`},
+				},
+				"captures in negated templates": {
+					`{"a": {"id": "x"}}`,
+					`{"a": {"<<NOT>>": {"id": "<<CAPTURE:id>>"}}}`,
+					[]string{`'expected' JSON contained an invalid directive '<<NOT>>' at '$.a': the template must not contain CAPTURE directives`},
+				},
+			} {
+				t.Run(name, func(t *testing.T) { tc.check(t) })
+			}
+		})
+
+		t.Run("across assertions", func(t *testing.T) {
+			t.Parallel()
+			tp := &testPrinter{messages: nil}
+			ja := jsonassert.New(tp)
+			ja.Assertf(`{"id": "abc", "n": 1.50}`, `{"id": "<<CAPTURE:userId>>", "n": "<<CAPTURE:n>>"}`)
+			ja.Assertf(`{"user": {"id": "abc"}}`, `{"user": {"id": "<<REF:userId>>"}}`)
+			ja.Assertf(`{"user": {"id": "abd"}}`, `{"user": {"id": "<<REF:userId>>"}}`)
+			if exp := []string{`expected value at '$.user.id' to be "abc", as captured by 'userId', but was "abd"`}; fmt.Sprint(tp.messages) != fmt.Sprint(exp) {
+				t.Errorf("expected messages %q but got %q", exp, tp.messages)
+			}
+			if got, ok := ja.Captured("userId"); !ok || got != "abc" {
+				t.Errorf("expected captured 'userId' to be \"abc\" but was %v (%v)", got, ok)
+			}
+			if got, ok := ja.Captured("n"); !ok || fmt.Sprint(got) != "1.50" {
+				t.Errorf("expected captured 'n' to be 1.50 but was %v (%v)", got, ok)
+			}
+			if got, ok := ja.Captured("unknown"); ok {
+				t.Errorf("expected nothing to be captured as 'unknown' but was %v", got)
+			}
+		})
+	})
+
+	t.Run("with SAME_AS directive", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]*testCase{
+			"equal values": {
+				`{"data": {"id": "1", "links": {"self": "/items/1"}}, "included": [{"id": "1", "self": "/items/1"}]}`,
+				`{"data": {"id": "<<STRING>>", "links": {"self": "<<STRING>>"}}, "included": [{"id": "<<SAME_AS:$.data.id>>", "self": "<<SAME_AS:$.data.links.self>>"}]}`,
+				nil,
+			},
+			"array elements and composite values": {
+				`{"items": [{"tags": ["a", "b"]}], "tags": ["a", "b"]}`,
+				`{"items": [{"tags": ["a", "b"]}], "tags": "<<SAME_AS:$.items[0].tags>>"}`,
+				nil,
+			},
+			"different values": {
+				`{"data": {"id": "1"}, "included": [{"id": "2", "name": "foo"}]}`,
+				`{"data": {"id": "<<STRING>>"}, "included": [{"id": "<<SAME_AS:$.data.id>>", "name": "bar"}]}`,
+				[]string{
+					`expected string at '$.included[0].name' to be 'bar' but was 'foo'`,
+					`expected value at '$.included[0].id' to be the same as at '$.data.id': "1", but was "2"`,
+				},
+			},
+			"values that look like directives": {
+				`{"a": "<<PRESENCE>>", "b": "zzz", "c": "<<PRESENCE>>"}`,
+				`{"a": "<<LITERAL:<<PRESENCE>>>>", "b": "<<SAME_AS:$.a>>", "c": "<<SAME_AS:$.a>>"}`,
+				[]string{`expected value at '$.b' to be the same as at '$.a': "\u003c\u003cPRESENCE\u003e\u003e", but was "zzz"`},
+			},
+			"missing values": {
+				`{"a": 1, "b": 1, "c": [1]}`,
+				`{"a": "<<SAME_AS:$.x>>", "b": "<<SAME_AS:$.c[1]>>", "c": ["<<SAME_AS:$.a.b>>"]}`,
+				[]string{
+					`expected value at '$.a' to be the same as at '$.x', but there was no object key 'x' at '$'`,
+					`expected value at '$.b' to be the same as at '$.c[1]', but there was no array element 1 at '$.c'`,
+					`expected value at '$.c[0]' to be the same as at '$.a.b', but there was no object key 'b' at '$.a'`,
+				},
+			},
+			"used in unordered arrays": {
+				`{"id": 2, "items": [1, 2]}`,
+				`{"id": "<<NUMBER>>", "items": ["<<UNORDERED>>", "<<SAME_AS:$.id>>", 1]}`,
+				nil,
+			},
+			"invalid paths": {
+				`{"a": 1, "b": [1]}`,
+				`{"a": "<<SAME_AS:a>>", "b": ["<<SAME_AS:$.b[x]>>"]}`,
+				[]string{
+					`'expected' JSON contained an invalid directive '<<SAME_AS:a>>' at '$.a': path 'a' must start with '$'`,
+					`'expected' JSON contained an invalid directive '<<SAME_AS:$.b[x]>>' at '$.b[0]': invalid array index 'x' in path '$.b[x]'`,
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) { tc.check(t) })
+		}
+	})
+
+	t.Run("with embedded JSON directives", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]*testCase{
+			"matching embedded JSON": {
+				`{"payload": "{\"a\": 1, \"b\": [\"x\"]}"}`,
+				`{"payload": {"<<JSON_STRING>>": {"a": 1, "b": ["x"]}}}`,
+				nil,
+			},
+			"directives within embedded JSON": {
+				`{"payload": "{\"id\": \"abc\", \"tags\": [\"b\", \"a\"]}"}`,
+				`{"payload": {"<<JSON_STRING>>": {"id": "<<STRING>>", "tags": ["<<UNORDERED>>", "a", "b"]}}}`,
+				nil,
+			},
+			"inline template": {
+				`{"payload": "[1, 2]"}`,
+				`{"payload": "<<JSON:[1, \"<<NUMBER>>\"]>>"}`,
+				nil,
+			},
+			"nested embedded JSON": {
+				`{"payload": "{\"inner\": \"{\\\"a\\\": 1}\"}"}`,
+				`{"payload": {"<<JSON_STRING>>": {"inner": {"<<JSON_STRING>>": {"a": 2}}}}}`,
+				[]string{`expected number at '$.payload<json>.inner<json>.a' to be '2.0000000' but was '1.0000000' (absolute tolerance 1e-06)`},
+			},
+			"differences within embedded JSON": {
+				`{"payload": "{\"a\": 1, \"b\": \"x\"}"}`,
+				`{"payload": {"<<JSON_STRING>>": {"a": 1, "b": "y"}}}`,
+				[]string{`expected string at '$.payload<json>.b' to be 'y' but was 'x'`},
+			},
+			"differences within inline template": {
+				`{"payload": "{\"a\": true}"}`,
+				`{"payload": "<<JSON:{\"a\": false}>>"}`,
+				[]string{`expected boolean at '$.payload<json>.a' to be false but was true`},
+			},
+			"string not containing JSON": {
+				`{"payload": "hello"}`,
+				`{"payload": {"<<JSON_STRING>>": {"a": 1}}}`,
+				[]string{`expected string at '$.payload' to contain JSON but was 'hello'`},
+			},
+			"non-string value": {
+				`{"payload": {"a": 1}}`,
+				`{"payload": {"<<JSON_STRING>>": {"a": 1}}}`,
+				[]string{`actual JSON (object) and expected JSON (string) were of different types at '$.payload'`},
+			},
+			"invalid inline template": {
+				`{"payload": "{}"}`,
+				`{"payload": "<<JSON:{>>"}`,
+				[]string{`'expected' JSON contained an invalid directive '<<JSON:{>>' at '$.payload': unable to identify JSON type of "{"`},
+			},
+			"other single-key objects": {
+				`{"a": {"<<PARTIAL>>": 1}}`,
+				`{"a": {"<<LITERAL:<<PARTIAL>>>>": 1}}`,
+				nil,
+			},
+		} {
+			t.Run(name, func(t *testing.T) { tc.check(t) })
+		}
+	})
+
+	t.Run("with ONEOF directive", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]*testCase{
+			"inline values": {
+				`{"status": "active", "code": 404, "parent": null}`,
+				`{"status": "<<ONEOF:pending,active,closed>>", "code": "<<ONEOF:400, 404>>", "parent": "<<ONEOF:null,1>>"}`,
+				nil,
+			},
+			"inline values that are not JSON strings": {
+				`{"a": "1", "b": "true"}`,
+				`{"a": "<<ONEOF:\"1\",\"2\">>", "b": "<<ONEOF:true,false>>"}`,
+				[]string{`expected value at '$.b' to be one of [true,false] but was "true"`},
+			},
+			"templates": {
+				`{"events": [{"type": "click", "x": 1}, {"type": "key", "key": "a"}]}`,
+				`{"events": ["<<EACH>>", {"<<ONEOF>>": [{"type": "click", "x": "<<NUMBER>>"}, {"type": "key", "key": "<<STRING>>"}]}]}`,
+				nil,
+			},
+			"no matching inline value": {
+				`{"status": "deleted"}`,
+				`{"status": "<<ONEOF:pending,active,closed>>"}`,
+				[]string{`expected value at '$.status' to be one of ["pending","active","closed"] but was "deleted"`},
+			},
+			"no matching template": {
+				`{"event": {"type": "scroll"}}`,
+				`{"event": {"<<ONEOF>>": [{"type": "click"}, {"type": "key"}]}}`,
+				[]string{`expected value at '$.event' to match one of 2 templates but was {"type":"scroll"}`},
+			},
+			"invalid templates": {
+				`{"a": 1, "b": 2}`,
+				`{"a": {"<<ONEOF>>": 1}, "b": {"<<ONEOF>>": []}}`,
+				[]string{
+					`'expected' JSON contained an invalid directive '<<ONEOF>>' at '$.a': expected a non-empty array of templates but was 1`,
+					`'expected' JSON contained an invalid directive '<<ONEOF>>' at '$.b': expected a non-empty array of templates but was []`,
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) { tc.check(t) })
+		}
+	})
+
+	t.Run("with NOT directive", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]*testCase{
+			"inline values": {
+				`{"a": "x", "b": 1, "c": "", "d": "ok", "e": "1"}`,
+				`{"a": "<<NOT:null>>", "b": "<<NOT:2>>", "c": "<<NOT:null>>", "d": "<<NOT:error>>", "e": "<<NOT:1>>"}`,
+				nil,
+			},
+			"equal inline values": {
+				`{"a": null, "b": 2, "c": "", "d": "error"}`,
+				`{"a": "<<NOT:null>>", "b": "<<NOT:2.0>>", "c": "<<NOT:\"\">>", "d": "<<NOT:error>>"}`,
+				[]string{
+					`expected value at '$.a' not to be null`,
+					`expected value at '$.b' not to be 2.0`,
+					`expected value at '$.c' not to be ""`,
+					`expected value at '$.d' not to be "error"`,
+				},
+			},
+			"templates": {
+				`{"result": {"status": "ok", "code": 200}}`,
+				`{"result": {"<<NOT>>": {"status": "error", "code": "<<NUMBER>>"}}}`,
+				nil,
+			},
+			"matching template": {
+				`{"result": {"status": "error", "code": 500}}`,
+				`{"result": {"<<NOT>>": {"status": "error", "code": "<<NUMBER>>"}}}`,
+				[]string{`expected value at '$.result' not to match the template but was {"code":500,"status":"error"}`},
+			},
+		} {
+			t.Run(name, func(t *testing.T) { tc.check(t) })
+		}
+	})
+
+	t.Run("with custom matchers", func(t *testing.T) {
+		t.Parallel()
+		currency := func(arg string, actual interface{}) error {
+			s, ok := actual.(string)
+			if !ok {
+				return fmt.Errorf("expected a string but was %v", actual)
+			}
+			if arg != "" && s != arg {
+				return fmt.Errorf("expected currency '%s' but was '%s'", arg, s)
+			}
+			if len(s) != 3 {
+				return fmt.Errorf("'%s' is not a currency code", s)
+			}
+			return nil
+		}
+		positive := func(_ string, actual interface{}) error {
+			if n, ok := actual.(json.Number); !ok || strings.HasPrefix(n.String(), "-") {
+				return fmt.Errorf("expected a positive number but was %v", actual)
+			}
+			return nil
+		}
+		for name, tc := range map[string]*testCase{
+			"satisfied matchers": {
+				`{"currency": "USD", "other": "JPY", "amount": 1.5}`,
+				`{"currency": "<<CURRENCY>>", "other": "<<CURRENCY:JPY>>", "amount": "<<POSITIVE>>"}`,
+				nil,
+			},
+			"unsatisfied matchers": {
+				`{"currency": "Dollars", "other": "USD", "amount": -1.5, "nested": [null]}`,
+				`{"currency": "<<CURRENCY>>", "other": "<<CURRENCY:JPY>>", "amount": "<<POSITIVE>>", "nested": ["<<CURRENCY>>"]}`,
+				[]string{
+					`expected CURRENCY at '$.currency': 'Dollars' is not a currency code`,
+					`expected CURRENCY at '$.other': expected currency 'JPY' but was 'USD'`,
+					`expected POSITIVE at '$.amount': expected a positive number but was -1.5`,
+					`expected CURRENCY at '$.nested[0]': expected a string but was <nil>`,
+				},
+			},
+			"unregistered directives are regular strings": {
+				`"USD"`,
+				`"<<COUNTRY>>"`,
+				[]string{`expected string at '$' to be '<<COUNTRY>>' but was 'USD'`},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				tp := &testPrinter{messages: nil}
+				ja := jsonassert.New(tp)
+				ja.RegisterMatcher("CURRENCY", currency)
+				ja.RegisterMatcher("POSITIVE", positive)
+				ja.Assert(tc.act, tc.exp)
+				tc.checkMessages(t, tp.messages)
+			})
+		}
+
+		t.Run("called once alongside captures", func(t *testing.T) {
+			calls := 0
+			ja := jsonassert.New(&testPrinter{messages: nil})
+			ja.RegisterMatcher("COUNTED", func(string, interface{}) error {
+				calls++
+				return nil
+			})
+			ja.Assert(`{"id": "x", "a": {"b": 1}, "c": "x"}`, `{"id": "<<CAPTURE:id>>", "a": {"b": "<<COUNTED>>"}, "c": "<<REF:id>>"}`)
+			if calls != 1 {
+				t.Errorf("expected the matcher to be called once but was called %d times", calls)
+			}
+		})
+	})
+
+	t.Run("with LITERAL directive", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]*testCase{
+			"literal directive strings": {
+				`{"a": "<<PRESENCE>>", "b": "<<REGEX:^a$>>", "c": "<<LITERAL:x>>"}`,
+				`{"a": "<<LITERAL:<<PRESENCE>>>>", "b": "<<LITERAL:<<REGEX:^a$>>>>", "c": "<<LITERAL:<<LITERAL:x>>>>"}`,
+				nil,
+			},
+			"literal array directives": {
+				`["<<UNORDERED>>", "b", "a"]`,
+				`["<<LITERAL:<<UNORDERED>>>>", "b", "a"]`,
+				nil,
+			},
+			"literal object key directives": {
+				`{"<<PARTIAL>>": true, "a": 1}`,
+				`{"<<LITERAL:<<PARTIAL>>>>": true, "a": 1}`,
+				nil,
+			},
+			"different strings": {
+				`["hello", 1]`,
+				`["<<LITERAL:<<PRESENCE>>>>", "<<LITERAL:1>>"]`,
+				[]string{
+					`expected string at '$[0]' to be '<<PRESENCE>>' but was 'hello'`,
+					`actual JSON (number) and expected JSON (string) were of different types at '$[1]'`,
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) { tc.check(t) })
+		}
+
+		t.Run("escaping format arguments", func(t *testing.T) {
+			t.Parallel()
+			tp := &testPrinter{messages: nil}
+			ja := jsonassert.New(tp)
+			ja.Assertf(`{"a": "<<PRESENCE>>", "b": "hello"}`, `{"a": "%s", "b": "%s"}`, ja.Escape("<<PRESENCE>>"), ja.Escape("hello"))
+			ja.Assertf(`["other", "hello"]`, `["%s", "%s"]`, ja.Escape("<<UNORDERED>>"), ja.Escape("hello"))
+			(&testCase{msgs: []string{
+				`expected string at '$[0]' to be '<<UNORDERED>>' but was 'other'`,
+			}}).checkMessages(t, tp.messages)
+		})
+	})
+
+	t.Run("with WithDirectiveDelimiters option", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]*testCase{
+			"custom delimiters are directives": {
+				`{"id": 1, "tags": ["b", "a"], "obj": {"a": 1, "b": 2}, "name": "Jayne"}`,
+				`{"id": "{{NUMBER}}", "tags": ["{{UNORDERED}}", "a", "b"], "obj": {"{{PARTIAL}}": true, "a": 1}, "name": "{{REGEX:^J}}"}`,
+				nil,
+			},
+			"default delimiters are regular strings": {
+				`{"cmd": "<<PRESENCE>>", "tags": ["<<UNORDERED>>", "a"]}`,
+				`{"cmd": "<<PRESENCE>>", "tags": ["<<UNORDERED>>", "a"]}`,
+				nil,
+			},
+			"default delimiters are compared literally": {
+				`{"cmd": "cat <<EOF", "id": 1}`,
+				`{"cmd": "<<STRING>>", "id": "{{STRING}}"}`,
+				[]string{
+					`expected string at '$.cmd' to be '<<STRING>>' but was 'cat <<EOF'`,
+					`actual JSON (number) and expected JSON (string) were of different types at '$.id'`,
+				},
+			},
+			"escaping custom delimiters": {
+				`"{{PRESENCE}}"`,
+				`"{{LITERAL:{{PRESENCE}}}}"`,
+				nil,
+			},
+		} {
+			t.Run(name, func(t *testing.T) { tc.check(t, jsonassert.WithDirectiveDelimiters("{{", "}}")) })
+		}
+
+		t.Run("escaping format arguments", func(t *testing.T) {
+			t.Parallel()
+			tp := &testPrinter{messages: nil}
+			ja := jsonassert.New(tp, jsonassert.WithDirectiveDelimiters("{{", "}}"))
+			if got := ja.Escape("{{PRESENCE}}"); got != "{{LITERAL:{{PRESENCE}}}}" {
+				t.Errorf("expected escaped string to be '{{LITERAL:{{PRESENCE}}}}' but was '%s'", got)
+			}
+			if got := ja.Escape("<<PRESENCE>>"); got != "<<PRESENCE>>" {
+				t.Errorf("expected escaped string to be '<<PRESENCE>>' but was '%s'", got)
+			}
+		})
+
+		t.Run("empty delimiters", func(t *testing.T) {
+			t.Parallel()
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected empty delimiters to panic")
+				}
+			}()
+			jsonassert.WithDirectiveDelimiters("", "}}")
+		})
 	})
 
 	t.Run("extra long strings should be formatted on a new line", func(t *testing.T) {
@@ -394,17 +1746,17 @@ potentially in a different order`,
 
 				`expected string at '$.emptyString' to be ' ' but was ''`,
 
-				`expected number at '$.zero' to be '0.0000100' but was '0.0000000'`,
+				`expected number at '$.zero' to be '0.0000100' but was '0.0000000' (absolute tolerance 1e-06)`,
 
 				`expected boolean at '$.boolean' to be true but was false`,
 
-				`expected number at '$.positiveInt' to be '124.0000000' but was '125.0000000'`,
+				`expected number at '$.positiveInt' to be '124.0000000' but was '125.0000000' (absolute tolerance 1e-06)`,
 
-				`expected number at '$.negativeInt' to be '-1246.0000000' but was '-1245.0000000'`,
+				`expected number at '$.negativeInt' to be '-1246.0000000' but was '-1245.0000000' (absolute tolerance 1e-06)`,
 
-				`expected number at '$.positiveFloats' to be '11.4500000' but was '12.4500000'`,
+				`expected number at '$.positiveFloats' to be '11.4500000' but was '12.4500000' (absolute tolerance 1e-06)`,
 
-				`expected number at '$.negativeFloats' to be '-13.3450000' but was '-12.3450000'`,
+				`expected number at '$.negativeFloats' to be '-13.3450000' but was '-12.3450000' (absolute tolerance 1e-06)`,
 
 				`expected string at '$.strings' to be 'hello world' but was 'hello 世界'`,
 
@@ -417,7 +1769,7 @@ potentially in a different order`,
 
 				`expected string at '$.nestedArray[0]' to be 'oop' but was 'boop'`,
 				`expected string at '$.nestedArray[1][0]' to be 'pob' but was 'poob'`,
-				`expected number at '$.nestedArray[1][1].asdf' to be '13.0000000' but was '14.0000000'`,
+				`expected number at '$.nestedArray[1][1].asdf' to be '13.0000000' but was '14.0000000' (absolute tolerance 1e-06)`,
 				`expected string at '$.nestedArray[1][1].bat' to be 'oi' but was 'boi'`,
 				`expected string at '$.nestedArray[1][1].oi[0]' to be 'by' but was 'boy'`,
 				`unexpected object key(s) ["n"] found at '$.nestedArray[2]'`,
@@ -449,18 +1801,22 @@ type testCase struct {
 	msgs     []string
 }
 
-func (tc *testCase) check(t *testing.T) {
+func (tc *testCase) check(t *testing.T, opts ...jsonassert.Option) {
 	t.Helper()
 	tp := &testPrinter{messages: nil}
-	jsonassert.New(tp).Assert(tc.act, tc.exp)
+	jsonassert.New(tp, opts...).Assert(tc.act, tc.exp)
+	tc.checkMessages(t, tp.messages)
+}
 
-	if got := len(tp.messages); got != len(tc.msgs) {
+func (tc *testCase) checkMessages(t *testing.T, messages []string) {
+	t.Helper()
+	if got := len(messages); got != len(tc.msgs) {
 		t.Errorf("expected %d assertion message(s) but got %d", len(tc.msgs), got)
 	}
 
 	for _, expMsg := range tc.msgs {
 		found := false
-		for _, printedMsg := range tp.messages {
+		for _, printedMsg := range messages {
 			found = found || expMsg == printedMsg
 		}
 		if !found {
@@ -468,7 +1824,7 @@ func (tc *testCase) check(t *testing.T) {
 		}
 	}
 
-	for _, printedMsg := range tp.messages {
+	for _, printedMsg := range messages {
 		found := false
 		for _, expMsg := range tc.msgs {
 			found = found || printedMsg == expMsg
@@ -0,0 +1,47 @@
+package jsonassert
+
+import "unicode/utf8"
+
+// checkLength verifies that length is within the bounds described by
+// constraint, e.g. ">=1". Returns false if the constraint itself is invalid.
+func (a *Asserter) checkLength(path, directive, constraint string, actType jsonType, length int) bool {
+	a.tt.Helper()
+	b, err := parseBounds(constraint)
+	if err != nil {
+		a.invalidDirective(path, directive, err)
+		return false
+	}
+	if !b.contains(float64(length)) {
+		a.tt.Errorf("length of %s at '%s' was out of bounds. Expected %s to be of length %s, but contained %d %s",
+			actType, path, actType, constraint, length, lengthUnit(actType))
+	}
+	return true
+}
+
+// lengthOf returns the number of elements in an array, characters in a
+// string, or keys in an object. The boolean return value is false for JSON
+// types that do not have a length.
+func lengthOf(act string, actType jsonType) (int, bool) {
+	switch actType { //nolint:exhaustive // other types don't have a length
+	case jsonArray:
+		arr, _ := extractArray(act)
+		return len(arr), true
+	case jsonString:
+		str, _ := extractString(act)
+		return utf8.RuneCountInString(str), true
+	case jsonObject:
+		obj, _ := extractObject(act)
+		return len(obj), true
+	}
+	return 0, false
+}
+
+func lengthUnit(actType jsonType) string {
+	switch actType { //nolint:exhaustive // other types don't have a length
+	case jsonString:
+		return "character(s)"
+	case jsonObject:
+		return "key(s)"
+	}
+	return "element(s)"
+}
@@ -0,0 +1,45 @@
+package jsonassert
+
+/*
+Matcher verifies the actual value found wherever a custom directive is used
+in the expected JSON, returning an error describing the problem if the value
+is not acceptable. The arg is the (potentially empty) text following the colon
+of the directive, e.g. "USD" for "<<MONEY:USD>>".
+The actual value is given as a string, json.Number, bool, nil,
+map[string]interface{}, or []interface{}, depending on its JSON type.
+*/
+type Matcher func(arg string, actual interface{}) error
+
+/*
+RegisterMatcher registers a custom directive with the given name, which
+may then be used in the expected JSON of subsequent assertions made by this
+Asserter. E.g.
+
+	ja.RegisterMatcher("MONEY", func(currency string, actual interface{}) error {
+		// verify that actual is a valid amount in the given currency
+	})
+	ja.Assertf(`{"price": "12.34 USD"}`, `{"price": "<<MONEY:USD>>"}`)
+
+Built-in directives, such as "<<PRESENCE>>", take precedence over custom
+directives with the same name.
+RegisterMatcher must not be called concurrently with any assertions.
+*/
+func (a *Asserter) RegisterMatcher(name string, m Matcher) {
+	a.matchers[name] = m
+}
+
+// checkMatcher verifies act against the custom matcher registered under the
+// given name. Returns false if there's no such matcher.
+func (a *Asserter) checkMatcher(path, act, name, arg string) bool {
+	a.tt.Helper()
+	m, ok := a.matchers[name]
+	if !ok {
+		return false
+	}
+	var val interface{}
+	_ = unmarshal(act, &val) // Known to be valid JSON by now.
+	if err := m(arg, val); err != nil {
+		a.tt.Errorf("expected %s at '%s': %s", name, path, err.Error())
+	}
+	return true
+}
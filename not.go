@@ -0,0 +1,33 @@
+package jsonassert
+
+import (
+	"errors"
+	"strings"
+)
+
+// checkInlineNot handles the "<<NOT:null>>" form of the NOT directive.
+func (a *Asserter) checkInlineNot(path, act, arg string) {
+	a.tt.Helper()
+	var val interface{}
+	_ = unmarshal(act, &val) // Known to be valid JSON by now.
+	if exp := parseInlineValue(arg); a.deepEqual(path, val, exp) {
+		a.tt.Errorf("expected value at '%s' not to be %s", path, serialize(exp))
+	}
+}
+
+// checkTemplateNot handles the {"<<NOT>>": ...} form of the NOT directive,
+// where the value is a template that the actual value must not match.
+func (a *Asserter) checkTemplateNot(path, act, directive string, template interface{}) {
+	a.tt.Helper()
+	if a.containsCapture(template) {
+		// The actual value never matches the template when the assertion
+		// passes, so there would be nothing to capture.
+		a.invalidDirective(path, directive, errors.New("the template must not contain CAPTURE directives"))
+		return
+	}
+	var val interface{}
+	_ = unmarshal(act, &val) // Known to be valid JSON by now.
+	if a.deepEqual(path, val, template) {
+		a.tt.Errorf("expected value at '%s' not to match the template but was %s", path, strings.TrimSpace(act))
+	}
+}
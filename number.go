@@ -1,8 +1,11 @@
 package jsonassert
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 )
 
 // This is *probably* good enough. Can change this to be even smaller if necessary
@@ -11,11 +14,119 @@ const (
 	bitSize = 64
 )
 
-func (a *Asserter) checkNumber(path string, act, exp float64) {
+func (a *Asserter) checkNumber(path, act, exp string) {
 	a.tt.Helper()
-	if diff := math.Abs(act - exp); diff > minDiff {
-		a.tt.Errorf("expected number at '%s' to be '%.7f' but was '%.7f'", path, exp, act)
+	if a.strictNumbers {
+		if act != exp {
+			a.tt.Errorf("expected number at '%s' to be written as '%s' but was '%s'", path, exp, act)
+		}
+		return
 	}
+	if a.preciseNumbers {
+		a.checkPreciseNumber(path, act, exp)
+		return
+	}
+	actNumber, _ := extractNumber(act)
+	expNumber, _ := extractNumber(exp)
+	tolerance := a.toleranceFor(path)
+	if tolerance.equal(actNumber, expNumber) {
+		return
+	}
+	if tolerance == defaultTolerance() {
+		a.tt.Errorf("expected number at '%s' to be '%.7f' but was '%.7f' (%s)", path, expNumber, actNumber, tolerance)
+	} else {
+		// Rounding to 7 decimals would hide the very differences that a
+		// custom tolerance is likely to be concerned with, so print the
+		// numbers as written instead.
+		a.tt.Errorf("expected number at '%s' to be '%s' but was '%s' (%s)", path, exp, act, tolerance)
+	}
+}
+
+// checkPreciseNumber compares the numbers exactly, without first converting
+// them to 64-bit floating point numbers, in order to avoid rounding errors in
+// e.g. large IDs or monetary amounts.
+func (a *Asserter) checkPreciseNumber(path, act, exp string) {
+	a.tt.Helper()
+	actNumber, _ := new(big.Rat).SetString(act)
+	expNumber, _ := new(big.Rat).SetString(exp)
+	if actNumber == nil || expNumber == nil || actNumber.Cmp(expNumber) != 0 {
+		a.tt.Errorf("expected number at '%s' to be '%s' but was '%s'", path, exp, act)
+	}
+}
+
+// checkNumberDirective verifies that act is a number, and that it satisfies
+// the given numeric directive, e.g. "<<RANGE:1..100>>" or "<<INT>>".
+func (a *Asserter) checkNumberDirective(path, act string, actType jsonType, directive, name, arg string) {
+	a.tt.Helper()
+	if actType != jsonNumber {
+		a.tt.Errorf("actual JSON (%s) and expected JSON (%s) were of different types at '%s'", actType, jsonNumber, path)
+		return
+	}
+	act = strings.TrimSpace(act)
+	actNumber, _ := extractNumber(act)
+	switch name {
+	case "INT":
+		if actNumber != math.Trunc(actNumber) {
+			a.tt.Errorf("expected number at '%s' to be an integer but was '%s'", path, act)
+		}
+	case "APPROX":
+		a.checkApprox(path, act, actNumber, directive, arg)
+	default:
+		if name == "NUMBER" && arg == "" {
+			return // Only cared about the type, which we've already checked.
+		}
+		a.checkBounds(path, act, actNumber, directive, arg)
+	}
+}
+
+// checkApprox verifies that the number is within the tolerance given in the
+// argument of an "<<APPROX:3.14±0.01>>" directive.
+func (a *Asserter) checkApprox(path, act string, actNumber float64, directive, arg string) {
+	a.tt.Helper()
+	target, tolerance, err := parseApprox(arg)
+	if err != nil {
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	if math.Abs(actNumber-target) > tolerance {
+		a.tt.Errorf("expected number at '%s' to be '%s' but was '%s'", path, arg, act)
+	}
+}
+
+// checkBounds verifies that the number satisfies the bounds given in the
+// argument of e.g. a "<<RANGE:1..100>>" directive.
+func (a *Asserter) checkBounds(path, act string, actNumber float64, directive, arg string) {
+	a.tt.Helper()
+	b, err := parseBounds(arg)
+	if err != nil {
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	if !b.contains(actNumber) {
+		a.tt.Errorf("expected number at '%s' to satisfy '%s' but was '%s'", path, arg, act)
+	}
+}
+
+// parseApprox parses an approximate number such as "3.14±0.01" (or
+// "3.14+/-0.01") into its target value and tolerance.
+func parseApprox(s string) (target, tolerance float64, err error) {
+	value, delta, ok := strings.Cut(s, "±")
+	if !ok {
+		value, delta, ok = strings.Cut(s, "+/-")
+	}
+	if !ok {
+		return 0, 0, fmt.Errorf("expected a number and tolerance such as '3.14±0.01' but was '%s'", s)
+	}
+	if target, err = parseBound(value); err != nil {
+		return 0, 0, err
+	}
+	if tolerance, err = parseBound(delta); err != nil {
+		return 0, 0, err
+	}
+	if tolerance < 0 {
+		return 0, 0, fmt.Errorf("tolerance '%s' must not be negative", strings.TrimSpace(delta))
+	}
+	return target, tolerance, nil
 }
 
 func extractNumber(n string) (float64, bool) {
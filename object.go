@@ -1,17 +1,21 @@
 package jsonassert
 
 import (
-	"encoding/json"
+	"fmt"
 	"strings"
 )
 
 func (a *Asserter) checkObject(path string, act, exp map[string]interface{}) {
 	a.tt.Helper()
-	if len(act) != len(exp) {
-		a.tt.Errorf("expected %d keys at '%s' but got %d keys", len(exp), path, len(act))
-	}
-	if unique := difference(act, exp); len(unique) != 0 {
-		a.tt.Errorf("unexpected object key(s) %+v found at '%s'", serialize(unique), path)
+	partial, exp := a.objectKeyDirectives(path, exp)
+	exp = a.withoutMissingOptionalKeys(act, exp)
+	if !partial {
+		if len(act) != len(exp) {
+			a.tt.Errorf("expected %d keys at '%s' but got %d keys", len(exp), path, len(act))
+		}
+		if unique := difference(act, exp); len(unique) != 0 {
+			a.tt.Errorf("unexpected object key(s) %+v found at '%s'", serialize(unique), path)
+		}
 	}
 	if unique := difference(exp, act); len(unique) != 0 {
 		a.tt.Errorf("expected object key(s) %+v missing at '%s'", serialize(unique), path)
@@ -23,6 +27,49 @@ func (a *Asserter) checkObject(path string, act, exp map[string]interface{}) {
 	}
 }
 
+// objectKeyDirectives determines whether keys in the actual object that are
+// not mentioned in the expected object should be ignored, and returns a copy
+// of exp without the "<<PARTIAL>>" directive key, if present, and with any
+// "<<LITERAL:key>>" keys unescaped.
+func (a *Asserter) objectKeyDirectives(path string, exp map[string]interface{}) (bool, map[string]interface{}) {
+	a.tt.Helper()
+	partial := a.partialObjects
+	res := make(map[string]interface{}, len(exp))
+	for key, val := range exp {
+		switch name, arg, _ := a.parseDirective(key); name {
+		case "PARTIAL":
+			if b, ok := val.(bool); ok {
+				partial = b
+			} else {
+				a.invalidDirective(path, key, fmt.Errorf("expected a boolean value but was %s", serialize(val)))
+			}
+		case "LITERAL":
+			res[arg] = val
+		default:
+			res[key] = val
+		}
+	}
+	return partial, res
+}
+
+// withoutMissingOptionalKeys returns a copy of exp without the keys that are
+// missing from act and are allowed to be so, i.e. keys whose expected value is
+// the "<<OPTIONAL>>" or "<<ABSENT>>" directive.
+func (a *Asserter) withoutMissingOptionalKeys(act, exp map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(exp))
+	for key, val := range exp {
+		if !contains(act, key) {
+			if s, ok := val.(string); ok {
+				if name, _, _ := a.parseDirective(s); name == "OPTIONAL" || name == "ABSENT" {
+					continue
+				}
+			}
+		}
+		res[key] = val
+	}
+	return res
+}
+
 func difference(act, exp map[string]interface{}) []string {
 	unique := []string{}
 	for key := range act {
@@ -48,5 +95,5 @@ func extractObject(s string) (map[string]interface{}, bool) {
 		return nil, false
 	}
 	var arr map[string]interface{}
-	return arr, json.Unmarshal([]byte(s), &arr) == nil
+	return arr, unmarshal(s, &arr) == nil
 }
@@ -0,0 +1,49 @@
+package jsonassert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkOneOf verifies that the actual value is equal to at least one of the
+// candidates, which may themselves be templates containing directives.
+func (a *Asserter) checkOneOf(path, act string, candidates []interface{}) bool {
+	a.tt.Helper()
+	var val interface{}
+	_ = unmarshal(act, &val) // Known to be valid JSON by now.
+	for _, candidate := range candidates {
+		if equal, captures := a.match(path, val, candidate); equal {
+			a.keepCaptures(captures)
+			return true
+		}
+	}
+	return false
+}
+
+// checkInlineOneOf handles the "<<ONEOF:pending,active,closed>>" form of the
+// ONEOF directive.
+func (a *Asserter) checkInlineOneOf(path, act, arg string) {
+	a.tt.Helper()
+	values := strings.Split(arg, ",")
+	candidates := make([]interface{}, len(values))
+	for i, v := range values {
+		candidates[i] = parseInlineValue(strings.TrimSpace(v))
+	}
+	if !a.checkOneOf(path, act, candidates) {
+		a.tt.Errorf("expected value at '%s' to be one of %s but was %s", path, serialize(candidates), strings.TrimSpace(act))
+	}
+}
+
+// checkTemplateOneOf handles the {"<<ONEOF>>": [...]} form of the ONEOF
+// directive, where each element of the array is a template.
+func (a *Asserter) checkTemplateOneOf(path, act, directive string, templates interface{}) {
+	a.tt.Helper()
+	candidates, isArray := templates.([]interface{})
+	if !isArray || len(candidates) == 0 {
+		a.invalidDirective(path, directive, fmt.Errorf("expected a non-empty array of templates but was %s", serialize(templates)))
+		return
+	}
+	if !a.checkOneOf(path, act, candidates) {
+		a.tt.Errorf("expected value at '%s' to match one of %d templates but was %s", path, len(candidates), strings.TrimSpace(act))
+	}
+}
@@ -0,0 +1,63 @@
+package jsonassert
+
+import "fmt"
+
+// Option configures the behavior of an Asserter. See New.
+type Option func(*Asserter)
+
+// WithPartialObjects makes the Asserter ignore any keys in the actual JSON
+// objects that are not present in the expected JSON objects, at any level of
+// nesting. An individual object may opt out of this behavior by including a
+// "<<PARTIAL>>": false key in the expected JSON.
+func WithPartialObjects() Option {
+	return func(a *Asserter) { a.partialObjects = true }
+}
+
+// WithNumberTolerance determines how close numbers must be in order to be
+// considered equal. See Tolerance.
+func WithNumberTolerance(t Tolerance) Option {
+	return func(a *Asserter) { a.tolerance = t }
+}
+
+// WithPathNumberTolerance overrides the tolerance used for numbers at the
+// given path, e.g. "$.price". Array indexes in the path may be replaced with
+// a wildcard, e.g. "$.items[*].price". If multiple paths match the same
+// number, then the tolerance that was given first applies.
+func WithPathNumberTolerance(path string, t Tolerance) Option {
+	return func(a *Asserter) {
+		a.pathTolerances = append(a.pathTolerances, pathTolerance{path: path, tolerance: t})
+	}
+}
+
+// WithPreciseNumbers makes the Asserter compare numbers exactly as they are
+// written in the JSON, rather than as 64-bit floating point numbers. This
+// allows for e.g. telling 9007199254740993 and 9007199254740992 apart, but
+// means that any configured Tolerance does not apply.
+func WithPreciseNumbers() Option {
+	return func(a *Asserter) { a.preciseNumbers = true }
+}
+
+// WithStrictNumberRepresentation makes the Asserter compare numbers by how
+// they are written in the JSON, such that e.g. 1, 1.0, and 1e0 are all
+// considered different. Use this if your consumers care about the
+// representation of numbers, and not just their values.
+func WithStrictNumberRepresentation() Option {
+	return func(a *Asserter) { a.strictNumbers = true }
+}
+
+/*
+WithDirectiveDelimiters changes the delimiters that surround directives in the
+expected JSON from the default "<<" and ">>", which is useful if your payloads
+contain strings that would otherwise be mistaken for directives. E.g.
+
+	ja := jsonassert.New(t, jsonassert.WithDirectiveDelimiters("{{", "}}"))
+	ja.Assertf(`{"id": 123, "cmd": "cat <<EOF"}`, `{"id": "{{NUMBER}}", "cmd": "cat <<EOF"}`)
+
+Panics if either delimiter is empty.
+*/
+func WithDirectiveDelimiters(prefix, suffix string) Option {
+	if prefix == "" || suffix == "" {
+		panic(fmt.Sprintf("jsonassert: directive delimiters must not be empty, but were '%s' and '%s'", prefix, suffix))
+	}
+	return func(a *Asserter) { a.directivePrefix, a.directiveSuffix = prefix, suffix }
+}
@@ -0,0 +1,95 @@
+package jsonassert
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// checkSameAs verifies that the actual value is equal to the value found at
+// another path of the same actual JSON.
+func (a *Asserter) checkSameAs(path, act, directive, otherPath string) {
+	a.tt.Helper()
+	var root, val interface{}
+	_ = unmarshal(a.root, &root) // Known to be valid JSON by now.
+	_ = unmarshal(act, &val)
+	other, err := resolvePath(root, otherPath)
+	var missing missingValueError
+	switch {
+	case errors.As(err, &missing):
+		a.tt.Errorf("expected value at '%s' to be the same as at '%s', but there was %s", path, otherPath, err.Error())
+		return
+	case err != nil:
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	if !a.sameValues(path, val, other) {
+		a.tt.Errorf("expected value at '%s' to be the same as at '%s': %s, but was %s", path, otherPath, serialize(other), strings.TrimSpace(act))
+	}
+}
+
+// missingValueError indicates that a path is valid, but that there is no
+// value at that path.
+type missingValueError string
+
+func (e missingValueError) Error() string { return string(e) }
+
+// resolvePath finds the value at the given path, e.g. "$.data.items[0].id",
+// within the decoded JSON value root.
+func resolvePath(root interface{}, path string) (interface{}, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("path '%s' must start with '$'", path)
+	}
+	val := root
+	for rest != "" {
+		var err error
+		switch rest[0] {
+		case '.':
+			val, rest, err = resolveKey(val, path, rest)
+		case '[':
+			val, rest, err = resolveIndex(val, path, rest)
+		default:
+			err = fmt.Errorf("unexpected '%c' in path '%s'", rest[0], path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return val, nil
+}
+
+// resolveKey resolves the ".key" step at the start of rest, the part of path
+// that is yet to be resolved, and returns the value along with what remains.
+func resolveKey(val interface{}, path, rest string) (interface{}, string, error) {
+	end := strings.IndexAny(rest[1:], ".[") + 1
+	if end == 0 {
+		end = len(rest)
+	}
+	key := rest[1:end]
+	obj, _ := val.(map[string]interface{})
+	child, found := obj[key]
+	if !found {
+		return nil, "", missingValueError(fmt.Sprintf("no object key '%s' at '%s'", key, strings.TrimSuffix(path, rest)))
+	}
+	return child, rest[end:], nil
+}
+
+// resolveIndex resolves the "[i]" step at the start of rest, the part of path
+// that is yet to be resolved, and returns the element along with what remains.
+func resolveIndex(val interface{}, path, rest string) (interface{}, string, error) {
+	end := strings.IndexByte(rest, ']')
+	if end == -1 {
+		return nil, "", fmt.Errorf("unterminated array index in path '%s'", path)
+	}
+	i, err := strconv.Atoi(rest[1:end])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid array index '%s' in path '%s'", rest[1:end], path)
+	}
+	arr, isArray := val.([]interface{})
+	if !isArray || i < 0 || i >= len(arr) {
+		return nil, "", missingValueError(fmt.Sprintf("no array element %d at '%s'", i, strings.TrimSuffix(path, rest)))
+	}
+	return arr[i], rest[end+1:], nil
+}
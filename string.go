@@ -2,20 +2,100 @@ package jsonassert
 
 import (
 	"encoding/json"
+	"errors"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 func (a *Asserter) checkString(path, act, exp string) {
 	a.tt.Helper()
-	if act != exp {
-		if len(exp+act) < maxMsgCharCount {
-			a.tt.Errorf("expected string at '%s' to be '%s' but was '%s'", path, exp, act)
-		} else {
-			a.tt.Errorf("expected string at '%s' to be\n'%s'\nbut was\n'%s'", path, exp, act)
+	if name, arg, ok := a.parseDirective(exp); ok && isStringDirective(name) {
+		a.checkStringDirective(path, act, exp, name, arg)
+		return
+	}
+	a.checkLiteralString(path, act, exp)
+}
+
+// stringComparison is a string directive that compares the actual string
+// against its argument, e.g. "<<PREFIX:https://>>".
+type stringComparison struct {
+	expectation string
+	compare     func(act, arg string) bool
+}
+
+//nolint:gochecknoglobals // never modified, just like the formats
+var stringComparisons = map[string]stringComparison{
+	"PREFIX":   {"to start with", strings.HasPrefix},
+	"SUFFIX":   {"to end with", strings.HasSuffix},
+	"CONTAINS": {"to contain", strings.Contains},
+	"IEQUALS":  {"to case-insensitively be", strings.EqualFold},
+}
+
+// isStringDirective reports whether the named directive is one that only
+// applies to strings, and is handled by checkString.
+func isStringDirective(name string) bool {
+	_, isComparison := stringComparisons[name]
+	return isComparison || name == "REGEX" || name == "STRLEN"
+}
+
+// checkStringArgument reports string directives without an argument, such as
+// "<<CONTAINS>>" used as a value, which would otherwise match any string.
+func (a *Asserter) checkStringArgument(path, directive, arg string) bool {
+	a.tt.Helper()
+	if arg == "" {
+		a.invalidDirective(path, directive, errors.New("an argument is required"))
+		return false
+	}
+	return true
+}
+
+func (a *Asserter) checkStringDirective(path, act, directive, name, arg string) {
+	a.tt.Helper()
+	switch name {
+	case "REGEX":
+		a.checkRegex(path, act, directive, arg)
+	case "STRLEN":
+		a.checkLength(path, directive, arg, jsonString, utf8.RuneCountInString(act))
+	default:
+		if c := stringComparisons[name]; !c.compare(act, arg) {
+			a.reportString(path, c.expectation, arg, act)
 		}
 	}
 }
 
+// checkLiteralString compares the strings as they are, without interpreting
+// any directives.
+func (a *Asserter) checkLiteralString(path, act, exp string) {
+	a.tt.Helper()
+	if act != exp {
+		a.reportString(path, "to be", exp, act)
+	}
+}
+
+func (a *Asserter) checkRegex(path, act, exp, pattern string) {
+	a.tt.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		a.invalidDirective(path, exp, err)
+		return
+	}
+	if !re.MatchString(act) {
+		a.reportString(path, "to match", pattern, act)
+	}
+}
+
+// reportString reports that the actual string did not meet the expectation,
+// e.g. "to be" the expected string.
+func (a *Asserter) reportString(path, expectation, exp, act string) {
+	a.tt.Helper()
+	if len(exp+act) < maxMsgCharCount {
+		a.tt.Errorf("expected string at '%s' %s '%s' but was '%s'", path, expectation, exp, act)
+	} else {
+		a.tt.Errorf("expected string at '%s' %s\n'%s'\nbut was\n'%s'", path, expectation, exp, act)
+	}
+}
+
 func extractString(s string) (string, bool) {
 	s = strings.TrimSpace(s)
 	if s == "" {
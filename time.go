@@ -0,0 +1,165 @@
+package jsonassert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// timeConstraint is the parsed argument of a "<<TIME:...>>" directive, e.g.
+// "UNIX,within=5s,at=2024-01-01T00:00:00Z".
+type timeConstraint struct {
+	// layout is one of "RFC3339", "UNIX" or "UNIX_MS", or empty if the
+	// layout should be inferred from the type of the actual value.
+	layout string
+	// at is the time that the actual time should be close to, or the zero
+	// time for the current time.
+	at        time.Time
+	within    time.Duration
+	hasWindow bool
+}
+
+func parseTimeConstraint(s string) (timeConstraint, error) {
+	var c timeConstraint
+	if s == "" {
+		return c, nil
+	}
+	for _, opt := range strings.Split(s, ",") {
+		if err := c.parseOption(strings.TrimSpace(opt)); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+// parseOption parses either a layout, e.g. "UNIX", or an option given as
+// key=value, e.g. "within=5s".
+func (c *timeConstraint) parseOption(opt string) error {
+	key, val, hasVal := strings.Cut(opt, "=")
+	switch {
+	case !hasVal:
+		return c.parseLayout(key)
+	case key == "within":
+		return c.parseWithin(val)
+	case key == "at":
+		return c.parseAt(val)
+	}
+	return fmt.Errorf("unknown option '%s'", key)
+}
+
+func (c *timeConstraint) parseLayout(s string) error {
+	switch s {
+	case "RFC3339", "UNIX", "UNIX_MS":
+		c.layout = s
+		return nil
+	}
+	return fmt.Errorf("unknown time layout '%s'", s)
+}
+
+func (c *timeConstraint) parseWithin(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return fmt.Errorf("could not parse '%s' as a non-negative duration", s)
+	}
+	c.within, c.hasWindow = d, true
+	return nil
+}
+
+func (c *timeConstraint) parseAt(s string) error {
+	if s != "now" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("could not parse '%s' as an RFC3339 time", s)
+		}
+		c.at = t
+	}
+	c.hasWindow = true
+	return nil
+}
+
+// checkTime verifies that the actual value is a time, and that it is within
+// the window given in the directive, if any.
+func (a *Asserter) checkTime(path, act string, actType jsonType, directive, arg string) {
+	a.tt.Helper()
+	c, err := parseTimeConstraint(arg)
+	if err != nil {
+		a.invalidDirective(path, directive, err)
+		return
+	}
+	layout := c.layout
+	if layout == "" {
+		layout = defaultLayout(act, actType)
+	}
+	actTime, ok := parseTime(layout, act, actType)
+	if !ok {
+		a.tt.Errorf("expected %s time at '%s' but was %s", layout, path, strings.TrimSpace(act))
+		return
+	}
+	if !c.hasWindow {
+		return
+	}
+	at, ref := c.at, c.at.Format(time.RFC3339Nano)
+	if at.IsZero() {
+		at, ref = time.Now(), "now"
+	}
+	if actTime.Before(at.Add(-c.within)) || actTime.After(at.Add(c.within)) {
+		a.tt.Errorf("expected time at '%s' to be within %s of %s but was %s, %s", path, c.within, ref, strings.TrimSpace(act), offset(actTime, at))
+	}
+}
+
+// defaultLayout infers the layout of the actual value when none is given.
+// Strings are RFC3339 times, and numbers are seconds since the Unix epoch,
+// unless they are so large that they can only reasonably be milliseconds.
+func defaultLayout(act string, actType jsonType) string {
+	if actType != jsonNumber {
+		return "RFC3339"
+	}
+	if n, _ := extractNumber(strings.TrimSpace(act)); math.Abs(n) >= minUnixMillis {
+		return "UNIX_MS"
+	}
+	return "UNIX"
+}
+
+// minUnixMillis is the magnitude from which epoch timestamps without a layout
+// are taken to be milliseconds. As seconds, these would be more than 3000
+// years away, whereas as milliseconds they are any time after early 1973.
+const minUnixMillis = 1e11
+
+// offset describes how much later or earlier t is than at, e.g. "10s later".
+func offset(t, at time.Time) string {
+	diff, direction := t.Sub(at), "later"
+	if t.Before(at) {
+		diff, direction = at.Sub(t), "earlier"
+	}
+	if diff == math.MaxInt64 {
+		// Durations saturate at roughly 292 years.
+		return "more than 290 years " + direction
+	}
+	return diff.String() + " " + direction
+}
+
+// parseTime interprets the actual JSON value as a time of the given layout.
+func parseTime(layout, act string, actType jsonType) (time.Time, bool) {
+	if layout == "RFC3339" {
+		s, isString := extractString(act)
+		t, err := time.Parse(time.RFC3339, s)
+		return t, isString && err == nil
+	}
+	if actType != jsonNumber {
+		return time.Time{}, false
+	}
+	seconds, _ := extractNumber(strings.TrimSpace(act))
+	if layout == "UNIX_MS" {
+		seconds /= float64(time.Second / time.Millisecond)
+	}
+	whole, frac := math.Modf(seconds)
+	if math.Abs(whole) > maxUnixSeconds {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))), true
+}
+
+// maxUnixSeconds keeps epoch timestamps well within the range of time.Time,
+// which would otherwise silently overflow.
+const maxUnixSeconds = 1 << 62
@@ -0,0 +1,137 @@
+package jsonassert
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type toleranceMode int
+
+const (
+	toleranceAbsolute toleranceMode = iota
+	toleranceExact
+	toleranceRelative
+	toleranceULP
+)
+
+// Tolerance determines how close two numbers must be in order to be
+// considered equal. By default, numbers are considered equal if they differ
+// by no more than 0.000001.
+type Tolerance struct {
+	mode  toleranceMode
+	value float64
+}
+
+// ExactTolerance considers numbers equal only if they are exactly equal as
+// 64-bit floating point numbers.
+func ExactTolerance() Tolerance {
+	return Tolerance{mode: toleranceExact, value: 0}
+}
+
+// AbsoluteTolerance considers numbers equal if they differ by no more than
+// epsilon.
+func AbsoluteTolerance(epsilon float64) Tolerance {
+	return Tolerance{mode: toleranceAbsolute, value: epsilon}
+}
+
+// RelativeTolerance considers numbers equal if they differ by no more than
+// epsilon times the larger of their absolute values. E.g. an epsilon of 0.01
+// allows numbers to differ by up to 1%.
+func RelativeTolerance(epsilon float64) Tolerance {
+	return Tolerance{mode: toleranceRelative, value: epsilon}
+}
+
+// ULPTolerance considers numbers equal if there are no more than ulps
+// representable 64-bit floating point numbers between them.
+func ULPTolerance(ulps uint64) Tolerance {
+	return Tolerance{mode: toleranceULP, value: float64(ulps)}
+}
+
+func defaultTolerance() Tolerance {
+	return AbsoluteTolerance(minDiff)
+}
+
+func (t Tolerance) String() string {
+	switch t.mode {
+	case toleranceExact:
+		return "exact comparison"
+	case toleranceRelative:
+		return fmt.Sprintf("relative tolerance %g", t.value)
+	case toleranceULP:
+		return fmt.Sprintf("tolerance %g ULP(s)", t.value)
+	case toleranceAbsolute: // Handled below, as this is the default
+	}
+	return fmt.Sprintf("absolute tolerance %g", t.value)
+}
+
+func (t Tolerance) equal(act, exp float64) bool {
+	switch t.mode {
+	case toleranceExact:
+		return act == exp
+	case toleranceRelative:
+		return math.Abs(act-exp) <= t.value*math.Max(math.Abs(act), math.Abs(exp))
+	case toleranceULP:
+		return float64(ulpDistance(act, exp)) <= t.value
+	case toleranceAbsolute: // Handled below, as this is the default
+	}
+	return math.Abs(act-exp) <= t.value
+}
+
+// ulpDistance returns the number of representable 64-bit floating point
+// numbers between a and b.
+func ulpDistance(a, b float64) uint64 {
+	ordA, ordB := orderedBits(a), orderedBits(b)
+	if ordA < ordB {
+		ordA, ordB = ordB, ordA
+	}
+	return uint64(ordA) - uint64(ordB)
+}
+
+// orderedBits maps a float64 onto an int64 such that the ordering of the
+// floats is preserved, and adjacent floats map to adjacent integers.
+func orderedBits(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
+
+type pathTolerance struct {
+	path      string
+	tolerance Tolerance
+}
+
+// toleranceFor returns the tolerance that applies to the number at the given
+// path, i.e. the first matching path specific tolerance if any, otherwise the
+// Asserter-wide tolerance.
+func (a *Asserter) toleranceFor(path string) Tolerance {
+	for _, pt := range a.pathTolerances {
+		if pathMatches(pt.path, path) {
+			return pt.tolerance
+		}
+	}
+	return a.tolerance
+}
+
+// pathMatches reports whether path, e.g. "$.items[3].price", matches pattern,
+// in which array indexes may be replaced with a wildcard, e.g.
+// "$.items[*].price".
+func pathMatches(pattern, path string) bool {
+	for pattern != "" {
+		if rest, ok := strings.CutPrefix(pattern, "[*]"); ok {
+			end := strings.IndexByte(path, ']')
+			if !strings.HasPrefix(path, "[") || end == -1 {
+				return false
+			}
+			pattern, path = rest, path[end+1:]
+			continue
+		}
+		if path == "" || pattern[0] != path[0] {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return path == ""
+}